@@ -1,6 +1,30 @@
 grammar Expression;
 
 expression
+   : orExpression (QUESTION expression COLON expression)?
+   ;
+
+orExpression
+   : xorExpression (OR xorExpression)*
+   ;
+
+xorExpression
+   : andExpression (XOR andExpression)*
+   ;
+
+andExpression
+   : equalityExpression (AND equalityExpression)*
+   ;
+
+equalityExpression
+   : relationalExpression ((EQ | NOT_EQ) relationalExpression)*
+   ;
+
+relationalExpression
+   : additiveExpression ((GT | LT | GTE | LTE) additiveExpression)*
+   ;
+
+additiveExpression
    : multiplyingExpression ((PLUS | MINUS) multiplyingExpression)*
    ;
 
@@ -13,14 +37,24 @@ powExpression
    ;
 
 signedAtom
-   : operator=(PLUS | MINUS) signedAtom
-   | function
-   | atom
-   | binaryOp
+   : operator=(PLUS | MINUS | NOT) signedAtom
+   | function accessor*
+   | atom accessor*
+   ;
+
+accessor
+   : POINT identifier
+   | LBRACKET expression RBRACKET
    ;
 
-binaryOp
-   : atom relop atom
+identifier
+   : VARIABLE
+   | PI
+   | EULER
+   | I
+   | TRUE
+   | FALSE
+   | NULL
    ;
 
 atom
@@ -29,6 +63,8 @@ atom
    | constant
    | LPAREN expression RPAREN
    | str
+   | boolean
+   | null
    ;
 
 str
@@ -39,6 +75,15 @@ scientific
    : SCIENTIFIC_NUMBER
    ;
 
+boolean
+   : TRUE
+   | FALSE
+   ;
+
+null
+   : NULL
+   ;
+
 constant
    : PI
    | EULER
@@ -53,16 +98,6 @@ function
    : fname=VARIABLE LPAREN expression (COMMA expression)* RPAREN
    ;
 
-relop
-   : EQ
-   | NOT_EQ
-   | GT
-   | LT
-   | OR
-   | AND
-   | XOR
-   ;
-
 LPAREN
    : '('
    ;
@@ -73,6 +108,16 @@ RPAREN
    ;
 
 
+LBRACKET
+   : '['
+   ;
+
+
+RBRACKET
+   : ']'
+   ;
+
+
 PLUS
    : '+'
    ;
@@ -107,6 +152,16 @@ LT
    ;
 
 
+GTE
+   : '>='
+   ;
+
+
+LTE
+   : '<='
+   ;
+
+
 EQ
    : '=='
    ;
@@ -115,6 +170,10 @@ NOT_EQ
    : '!='
    ;
 
+NOT
+   : '!'
+   ;
+
 OR
    : '||'
    ;
@@ -143,6 +202,16 @@ POW
    ;
 
 
+QUESTION
+   : '?'
+   ;
+
+
+COLON
+   : ':'
+   ;
+
+
 PI
    : 'pi'
    ;
@@ -158,20 +227,36 @@ I
    ;
 
 
+TRUE
+   : 'true'
+   ;
+
+
+FALSE
+   : 'false'
+   ;
+
+
+NULL
+   : 'null'
+   ;
+
+
 VARIABLE
    : VALID_ID_START VALID_ID_CHAR*
    ;
 
 QUOTED_STRING
-   : QUOTE ( ESCAPED_QUOTE | ~('\n'|'\r') )*? QUOTE
+   : QUOTE ( ESCAPE | ~('"'|'\\'|'\n'|'\r') )* QUOTE
+   | '\'' ( ESCAPE | ~('\''|'\\'|'\n'|'\r') )* '\''
    ;
 
 QUOTE
    : '"'
    ;
 
-fragment ESCAPED_QUOTE
-   : '\\"'
+fragment ESCAPE
+   : '\\' ~('\n'|'\r')
    ;
 
 fragment VALID_ID_START
@@ -0,0 +1,353 @@
+package expressions
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/antlr/antlr4/runtime/Go/antlr"
+	"github.com/jamillosantos/go-expressions/parser"
+)
+
+// The parser generated by ANTLR from Expression.g4 is kept out of the package,
+// only as the reference the native parser is compared to in the tests.
+
+func NewExpression(expression antlr.Tree) Expression {
+	return NewCompiler().NewExpression(expression)
+}
+
+// NewExpression builds the expression of a parse tree. The nodes keep the
+// span of the source they were built from, so runtime errors can point to it.
+func (c *Compiler) NewExpression(expression antlr.Tree) Expression {
+	r := c.newExpression(expression)
+	if ctx, ok := expression.(antlr.ParserRuleContext); ok {
+		if s, ok := r.(Spanned); ok && s.Span() == nil {
+			s.SetSpan(newSpan(ctx.GetStart(), ctx.GetStop()))
+		}
+	}
+	return r
+}
+
+// setSpan sets the span of a node that covers the source from the start of
+// from to the end of to.
+func setSpan(e Expression, from, to antlr.ParserRuleContext) Expression {
+	if s, ok := e.(Spanned); ok {
+		s.SetSpan(newSpan(from.GetStart(), to.GetStop()))
+	}
+	return e
+}
+
+func (c *Compiler) newExpression(expression antlr.Tree) Expression {
+	switch e := expression.(type) {
+	case *parser.VariableContext:
+		return c.variable(e.GetText())
+	case *parser.ConstantContext:
+		return c.variable(e.GetText())
+	case *parser.ScientificContext:
+		return NewExpressionValue(numberValue(e.GetText()))
+	case *parser.AtomContext:
+		if e.GetChildCount() == 1 {
+			return c.NewExpression(e.GetChild(0))
+		} else {
+			return c.NewExpression(e.GetChild(1))
+		}
+	case *parser.SignedAtomContext:
+		if e.GetOperator() == nil {
+			r := c.NewExpression(e.GetChild(0))
+			for _, a := range e.AllAccessor() {
+				accessor := a.(*parser.AccessorContext)
+				if accessor.Identifier() != nil {
+					r = setSpan(NewExpressionMember(r, accessor.Identifier().GetText()), e, accessor)
+				} else {
+					r = setSpan(NewExpressionIndex(r, c.NewExpression(accessor.Expression())), e, accessor)
+				}
+			}
+			return r
+		}
+		if e.GetOperator().GetText() == "!" {
+			return NewExpressionNot(c.NewExpression(e.GetChild(1)))
+		}
+		expr := NewExpressionMultiple()
+		expr.Add("", NewExpressionValue(0))
+		expr.Add(e.GetOperator().GetText(), c.NewExpression(e.GetChild(1)))
+		return expr
+	case *parser.ExpressionContext:
+		if e.GetChildCount() == 1 {
+			return c.NewExpression(e.GetChild(0))
+		}
+		return NewExpressionConditional(c.NewExpression(e.OrExpression()), c.NewExpression(e.Expression(0)), c.NewExpression(e.Expression(1)))
+	case *parser.OrExpressionContext:
+		if e.GetChildCount() == 1 {
+			return c.NewExpression(e.GetChild(0))
+		} else {
+			var r Expression
+			for i, xe := range e.AllXorExpression() {
+				if i == 0 {
+					r = c.NewExpression(xe)
+				} else {
+					r = setSpan(NewExpressionLogical(r, e.GetChild(i*2 - 1).(*antlr.TerminalNodeImpl).GetText(), c.NewExpression(xe)), e, xe)
+				}
+			}
+			return r
+		}
+	case *parser.XorExpressionContext:
+		if e.GetChildCount() == 1 {
+			return c.NewExpression(e.GetChild(0))
+		} else {
+			var r Expression
+			for i, ae := range e.AllAndExpression() {
+				if i == 0 {
+					r = c.NewExpression(ae)
+				} else {
+					r = setSpan(NewExpressionLogical(r, e.GetChild(i*2 - 1).(*antlr.TerminalNodeImpl).GetText(), c.NewExpression(ae)), e, ae)
+				}
+			}
+			return r
+		}
+	case *parser.AndExpressionContext:
+		if e.GetChildCount() == 1 {
+			return c.NewExpression(e.GetChild(0))
+		} else {
+			var r Expression
+			for i, ee := range e.AllEqualityExpression() {
+				if i == 0 {
+					r = c.NewExpression(ee)
+				} else {
+					r = setSpan(NewExpressionLogical(r, e.GetChild(i*2 - 1).(*antlr.TerminalNodeImpl).GetText(), c.NewExpression(ee)), e, ee)
+				}
+			}
+			return r
+		}
+	case *parser.EqualityExpressionContext:
+		if e.GetChildCount() == 1 {
+			return c.NewExpression(e.GetChild(0))
+		} else {
+			var r Expression
+			for i, re := range e.AllRelationalExpression() {
+				if i == 0 {
+					r = c.NewExpression(re)
+				} else {
+					r = setSpan(NewExpressionBinary(r, e.GetChild(i*2 - 1).(*antlr.TerminalNodeImpl).GetText(), c.NewExpression(re)), e, re)
+				}
+			}
+			return r
+		}
+	case *parser.RelationalExpressionContext:
+		if e.GetChildCount() == 1 {
+			return c.NewExpression(e.GetChild(0))
+		} else {
+			var r Expression
+			for i, ae := range e.AllAdditiveExpression() {
+				if i == 0 {
+					r = c.NewExpression(ae)
+				} else {
+					r = setSpan(NewExpressionBinary(r, e.GetChild(i*2 - 1).(*antlr.TerminalNodeImpl).GetText(), c.NewExpression(ae)), e, ae)
+				}
+			}
+			return r
+		}
+	case *parser.AdditiveExpressionContext:
+		if e.GetChildCount() == 1 {
+			return c.NewExpression(e.GetChild(0))
+		} else {
+			r := NewExpressionMultiple()
+			for i, me := range e.AllMultiplyingExpression() {
+				if i == 0 {
+					r.Add("", c.NewExpression(me))
+				} else {
+					r.Add(e.GetChild(i*2 - 1).(*antlr.TerminalNodeImpl).GetText(), c.NewExpression(me))
+				}
+			}
+			return r
+		}
+	case *parser.MultiplyingExpressionContext:
+		childCount := e.GetChildCount()
+		if childCount == 1 {
+			return c.NewExpression(e.GetChild(0))
+		} else {
+			r := NewExpressionMultiple()
+			for i, me := range e.AllPowExpression() {
+				if i == 0 {
+					r.Add("", c.NewExpression(me))
+				} else {
+					r.Add(e.GetChild(i*2 - 1).(*antlr.TerminalNodeImpl).GetText(), c.NewExpression(me))
+				}
+			}
+			return r
+		}
+	case *parser.PowExpressionContext:
+		if e.GetChildCount() == 1 {
+			return c.NewExpression(e.GetChild(0))
+		} else {
+			r := NewExpressionMultiple()
+			for i, me := range e.AllSignedAtom() {
+				if i == 0 {
+					r.Add("", c.NewExpression(me))
+				} else {
+					r.Add(e.GetChild(i*2 - 1).(*antlr.TerminalNodeImpl).GetText(), c.NewExpression(me))
+				}
+			}
+			return r
+		}
+	case *parser.FunctionContext:
+		eParams := e.AllExpression()
+		params := make([]Expression, 0, len(eParams))
+		for _, p := range eParams {
+			params = append(params, c.NewExpression(p))
+		}
+		return NewExpressionFunction(e.GetFname().GetText(), params...)
+	case *parser.BooleanContext:
+		return NewExpressionValue(e.GetText() == "true")
+	case *parser.NullContext:
+		return NewExpressionValue(nil)
+	case *parser.StrContext:
+		return NewExpressionValue(stringValue(e.GetText()))
+	}
+	return nil
+}
+
+type CaptureErrorListener struct {
+	source string
+	errors []*SyntaxError
+}
+
+func NewCaptureErrorListener() *CaptureErrorListener {
+	return &CaptureErrorListener{
+		errors: make([]*SyntaxError, 0),
+	}
+}
+
+func (errorListener *CaptureErrorListener) HasErrors() bool {
+	return len(errorListener.errors) > 0
+}
+
+func (errorListener *CaptureErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	err := &SyntaxError{
+		Line:    line,
+		Column:  column,
+		Message: msg,
+	}
+	length := 1
+	if token, ok := offendingSymbol.(antlr.Token); ok {
+		if token.GetTokenType() == antlr.TokenEOF {
+			err.Offending = "<EOF>"
+		} else {
+			err.Offending = token.GetText()
+			length = utf8.RuneCountInString(err.Offending)
+		}
+	}
+	if p, ok := recognizer.(antlr.Parser); ok && p.GetState() >= 0 {
+		err.Expected = expectedTokens(p)
+	}
+	err.Snippet = snippet(errorListener.source, line, column, length)
+	errorListener.errors = append(errorListener.errors, err)
+}
+
+func (errorListener *CaptureErrorListener) CompileError() *CompileError {
+	return &CompileError{
+		Source: errorListener.source,
+		Errors: errorListener.errors,
+	}
+}
+
+func expectedTokens(p antlr.Parser) []string {
+	expected := p.GetExpectedTokens()
+	if expected == nil {
+		return nil
+	}
+	// IntervalSet does not expose its items, so they are taken from its
+	// string representation: "{'(', VARIABLE}" or a single name.
+	names := strings.TrimSuffix(strings.TrimPrefix(expected.StringVerbose(p.GetLiteralNames(), p.GetSymbolicNames(), false), "{"), "}")
+	if names == "" {
+		return nil
+	}
+	return strings.Split(names, ", ")
+}
+
+func (errorListener *CaptureErrorListener) ReportAmbiguity(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex int, exact bool, ambigAlts *antlr.BitSet, configs antlr.ATNConfigSet) {
+}
+
+func (errorListener *CaptureErrorListener) ReportAttemptingFullContext(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex int, conflictingAlts *antlr.BitSet, configs antlr.ATNConfigSet) {
+}
+
+func (errorListener *CaptureErrorListener) ReportContextSensitivity(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex, prediction int, configs antlr.ATNConfigSet) {
+}
+
+// CompileANTLR parses an expression with the parser generated by ANTLR from
+// Expression.g4.
+func (c *Compiler) CompileANTLR(expression string) (Expression, error) {
+	input := antlr.NewInputStream(expression)
+	lexer := parser.NewExpressionLexer(input)
+	stream := antlr.NewCommonTokenStream(lexer, 0)
+	p := parser.NewExpressionParser(stream)
+
+	errorListener := NewCaptureErrorListener()
+	errorListener.source = expression
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(errorListener)
+	p.RemoveErrorListeners()
+	p.AddErrorListener(errorListener)
+	p.BuildParseTrees = true
+	expr := p.Expression()
+	if token := stream.LT(1); token.GetTokenType() != antlr.TokenEOF {
+		p.NotifyErrorListeners(fmt.Sprintf("extraneous input '%s' expecting <EOF>", token.GetText()), token, nil)
+		errorListener.errors[len(errorListener.errors)-1].Expected = []string{"<EOF>"}
+	}
+	if errorListener.HasErrors() {
+		return nil, errorListener.CompileError()
+	}
+	if c.maxDepth > 0 && exceedsNesting(expr, c.maxDepth) {
+		return nil, &LimitExceededError{Limit: LimitDepth, Max: c.maxDepth}
+	}
+	r := c.NewExpression(expr)
+	if err := c.validate(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// exceedsNesting reports whether signedAtom rules and branches of
+// conditionals are nested more than max levels in a parse tree, the nesting
+// the native parser limits, so NewExpression does not go deeper than that.
+func exceedsNesting(tree antlr.Tree, max int) bool {
+	switch t := tree.(type) {
+	case *parser.SignedAtomContext:
+		if max == 0 {
+			return true
+		}
+		max--
+	case *parser.ExpressionContext:
+		if t.QUESTION() != nil {
+			if max == 0 {
+				return true
+			}
+			return exceedsNesting(t.OrExpression(), max) || exceedsNesting(t.Expression(0), max-1) || exceedsNesting(t.Expression(1), max-1)
+		}
+	}
+	for _, child := range tree.GetChildren() {
+		if exceedsNesting(child, max) {
+			return true
+		}
+	}
+	return false
+}
+
+// newSpan builds the span between two tokens, both included.
+func newSpan(start, stop antlr.Token) *Span {
+	if start == nil || stop == nil {
+		return nil
+	}
+	span := &Span{
+		Start:  start.GetStart(),
+		End:    stop.GetStop() + 1,
+		Line:   start.GetLine(),
+		Column: start.GetColumn(),
+	}
+	if stop.GetTokenType() == antlr.TokenEOF {
+		span.End = stop.GetStart()
+	}
+	if input := start.GetInputStream(); input != nil && input.Size() > 0 {
+		span.source = []rune(input.GetText(0, input.Size()-1))
+	}
+	return span
+}
@@ -0,0 +1,362 @@
+package expressions
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Type int
+
+const (
+	TypeAny Type = iota
+	TypeNumber
+	TypeString
+	TypeBool
+	TypeNull
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeNumber:
+		return "number"
+	case TypeString:
+		return "string"
+	case TypeBool:
+		return "bool"
+	case TypeNull:
+		return "null"
+	}
+	return "any"
+}
+
+// accepts reports whether a value of type v can be used where t is expected.
+func (t Type) accepts(v Type) bool {
+	return t == TypeAny || v == TypeAny || t == v
+}
+
+// Signature describes the parameters and the result of a function. Optional
+// is the number of parameters, at the end of Params, that can be omitted. When
+// Variadic is set, the last parameter can be repeated any number of times,
+// including none.
+type Signature struct {
+	Params   []Type
+	Optional int
+	Variadic bool
+	Result   Type
+}
+
+// MinParams returns how many parameters must be given.
+func (signature *Signature) MinParams() int {
+	min := len(signature.Params) - signature.Optional
+	if signature.Variadic {
+		min--
+	}
+	if min < 0 {
+		return 0
+	}
+	return min
+}
+
+// MaxParams returns how many parameters can be given, or -1 when there is no
+// limit.
+func (signature *Signature) MaxParams() int {
+	if signature.Variadic {
+		return -1
+	}
+	return len(signature.Params)
+}
+
+var DefaultSignatures = map[string]*Signature{
+	"cos":   {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"cosh":  {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"acos":  {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"acosh": {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"sin":   {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"sinh":  {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"asin":  {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"asinh": {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"sqrt":  {Params: []Type{TypeNumber, TypeNumber}, Optional: 1, Result: TypeNumber},
+	"tan":   {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"atan":  {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"atan2": {Params: []Type{TypeNumber, TypeNumber}, Result: TypeNumber},
+	"atanh": {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"log":   {Params: []Type{TypeNumber}, Result: TypeNumber},
+	"if":    {Params: []Type{TypeAny, TypeAny, TypeAny}, Result: TypeAny},
+
+	"len":        {Params: []Type{TypeString}, Result: TypeNumber},
+	"upper":      {Params: []Type{TypeString}, Result: TypeString},
+	"lower":      {Params: []Type{TypeString}, Result: TypeString},
+	"trim":       {Params: []Type{TypeString}, Result: TypeString},
+	"substr":     {Params: []Type{TypeString, TypeNumber, TypeNumber}, Optional: 1, Result: TypeString},
+	"contains":   {Params: []Type{TypeString, TypeString}, Result: TypeBool},
+	"startsWith": {Params: []Type{TypeString, TypeString}, Result: TypeBool},
+	"endsWith":   {Params: []Type{TypeString, TypeString}, Result: TypeBool},
+	"replace":    {Params: []Type{TypeString, TypeString, TypeString}, Result: TypeString},
+	"split":      {Params: []Type{TypeString, TypeString}, Result: TypeAny},
+	"join":       {Params: []Type{TypeAny, TypeString}, Result: TypeString},
+	"padLeft":    {Params: []Type{TypeString, TypeNumber, TypeString}, Optional: 1, Result: TypeString},
+	"repeat":     {Params: []Type{TypeString, TypeNumber}, Result: TypeString},
+	"format":     {Params: []Type{TypeString, TypeAny}, Variadic: true, Result: TypeString},
+}
+
+// Schema declares the variables and functions an expression can use. Members
+// of a variable can be declared with their paths (e.g. "order.total"); the
+// members that are not declared are accepted with any type.
+type Schema struct {
+	variables map[string]Type
+	functions map[string]*Signature
+}
+
+// NewSchema creates a schema with no variables and the signatures of the
+// default functions.
+func NewSchema() *Schema {
+	schema := &Schema{
+		variables: make(map[string]Type),
+		functions: make(map[string]*Signature, len(DefaultSignatures)),
+	}
+	for name, signature := range DefaultSignatures {
+		schema.functions[name] = signature
+	}
+	return schema
+}
+
+func (schema *Schema) SetVariable(name string, t Type) *Schema {
+	schema.variables[name] = t
+	return schema
+}
+
+func (schema *Schema) SetFunction(name string, signature *Signature) *Schema {
+	schema.functions[name] = signature
+	return schema
+}
+
+func (schema *Schema) RemoveFunction(name string) *Schema {
+	delete(schema.functions, name)
+	return schema
+}
+
+// variable finds the type of a variable or path. Variables that are only
+// declared through the paths of their members are objects of any type.
+func (schema *Schema) variable(name string) (Type, bool) {
+	if t, ok := schema.variables[name]; ok {
+		return t, true
+	}
+	for declared := range schema.variables {
+		if strings.HasPrefix(declared, name+".") || strings.HasPrefix(declared, name+"[") {
+			return TypeAny, true
+		}
+	}
+	return TypeAny, false
+}
+
+type TypeError struct {
+	Span    *Span
+	Message string
+}
+
+func (err *TypeError) Error() string {
+	if err.Span == nil {
+		return err.Message
+	}
+	return fmt.Sprintf("%s (%s)", err.Message, err.Span.String())
+}
+
+// CheckError holds all the errors found by Check.
+type CheckError struct {
+	Errors []*TypeError
+}
+
+func (err *CheckError) Error() string {
+	msgs := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Check validates an expression against a schema without solving it. It
+// reports operands of the wrong type, variables that are not declared and
+// functions that are unknown or called with the wrong number of parameters.
+// Expressions of types unknown to the checker are accepted with any type.
+func Check(expression Expression, schema *Schema) error {
+	c := &checker{
+		schema: schema,
+	}
+	c.check(expression)
+	if len(c.errors) > 0 {
+		return &CheckError{
+			Errors: c.errors,
+		}
+	}
+	return nil
+}
+
+type checker struct {
+	schema *Schema
+	errors []*TypeError
+}
+
+func (c *checker) fail(e Expression, message string) {
+	err := &TypeError{
+		Message: message,
+	}
+	if s, ok := e.(Spanned); ok {
+		err.Span = s.Span()
+	}
+	c.errors = append(c.errors, err)
+}
+
+// expect checks e and reports an error when its type is not accepted by t.
+func (c *checker) expect(e Expression, t Type) {
+	c.accept(e, t, c.check(e))
+}
+
+// accept reports e when its type, already checked, is not accepted where t is
+// expected.
+func (c *checker) accept(e Expression, t Type, actual Type) {
+	if !t.accepts(actual) {
+		c.fail(e, fmt.Sprintf("A %s was expected, got %s.", t, actual))
+	}
+}
+
+// operandsType returns the type the first of the operands decides: strings
+// when it is, or may be, a string and numbers otherwise.
+func operandsType(types []Type) Type {
+	for i, t := range types {
+		if t == TypeString && (i == 0 || types[0] == TypeAny) {
+			return TypeString
+		}
+	}
+	return TypeNumber
+}
+
+func (c *checker) check(expression Expression) Type {
+	switch e := expression.(type) {
+	case *ExpressionValue:
+		return typeOf(e.value)
+	case *ExpressionField:
+		t, ok := c.schema.variable(e.field)
+		if !ok {
+			c.fail(e, fmt.Sprintf("The variable '%s' is not declared.", e.field))
+		}
+		return t
+	case *ExpressionMember:
+		if path, ok := declaredPath(e); ok {
+			if t, ok := c.schema.variable(path); ok {
+				return t
+			}
+		}
+		if t := c.check(e.expression); t != TypeAny {
+			c.fail(e, fmt.Sprintf("Cannot access members of %s.", t))
+		}
+		return TypeAny
+	case *ExpressionIndex:
+		if path, ok := declaredPath(e); ok {
+			if t, ok := c.schema.variable(path); ok {
+				return t
+			}
+		}
+		t := c.check(e.expression)
+		index := c.check(e.index)
+		switch t {
+		case TypeAny:
+		case TypeString:
+			c.accept(e.index, TypeNumber, index)
+			return TypeString
+		default:
+			c.fail(e, fmt.Sprintf("Cannot index %s.", t))
+		}
+		return TypeAny
+	case *ExpressionMultiple:
+		types := make([]Type, len(e.terms))
+		for i, p := range e.terms {
+			types[i] = c.check(p.expression)
+		}
+		t := TypeNumber
+		if concatenates(e.terms) {
+			t = operandsType(types)
+		}
+		for i, p := range e.terms {
+			c.accept(p.expression, t, types[i])
+		}
+		return t
+	case *ExpressionBinary:
+		left, right := c.check(e.left), c.check(e.right)
+		switch e.operator {
+		case "==", "!=":
+		default:
+			t := operandsType([]Type{left, right})
+			c.accept(e.left, t, left)
+			c.accept(e.right, t, right)
+		}
+		return TypeBool
+	case *ExpressionLogical:
+		c.check(e.left)
+		c.check(e.right)
+		return TypeBool
+	case *ExpressionNot:
+		c.check(e.expression)
+		return TypeBool
+	case *ExpressionBrackets:
+		return c.check(e.inner)
+	case *ExpressionConditional:
+		c.check(e.condition)
+		then, otherwise := c.check(e.then), c.check(e.otherwise)
+		if then != otherwise {
+			return TypeAny
+		}
+		return then
+	case *ExpressionFunction:
+		signature, ok := c.schema.functions[e.name]
+		if !ok {
+			c.fail(e, fmt.Sprintf("The function '%s' is not defined.", e.name))
+			for _, p := range e.params {
+				c.check(p)
+			}
+			return TypeAny
+		}
+		min, max := signature.MinParams(), signature.MaxParams()
+		if len(e.params) < min || (max >= 0 && len(e.params) > max) {
+			c.fail(e, (&ParameterError{
+				name:       e.name,
+				paramCount: uint(min),
+				optional:   uint(max - min),
+				variadic:   signature.Variadic,
+			}).Error())
+		}
+		for i, p := range e.params {
+			if i < len(signature.Params) {
+				c.expect(p, signature.Params[i])
+			} else if signature.Variadic {
+				c.expect(p, signature.Params[len(signature.Params)-1])
+			} else {
+				c.check(p)
+			}
+		}
+		return signature.Result
+	}
+	return TypeAny
+}
+
+// declaredPath renders the path of fields, members and indexes by constant
+// values, the way they are declared in a Schema.
+func declaredPath(e Expression) (string, bool) {
+	path := describePath(e)
+	return path, !strings.Contains(path, "[...]") && !strings.Contains(path, "the expression")
+}
+
+func typeOf(v interface{}) Type {
+	if v == nil {
+		return TypeNull
+	}
+	if _, ok := normalizeNumber(v); ok {
+		return TypeNumber
+	}
+	switch v.(type) {
+	case string:
+		return TypeString
+	case bool:
+		return TypeBool
+	}
+	return TypeAny
+}
@@ -0,0 +1,181 @@
+package expressions_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"github.com/jamillosantos/go-expressions"
+	. "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestCheck(t *testing.T) {
+	g := Goblin(t)
+
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Check", func() {
+		schema := expressions.NewSchema().
+			SetVariable("a", expressions.TypeNumber).
+			SetVariable("name", expressions.TypeString).
+			SetVariable("active", expressions.TypeBool).
+			SetVariable("order.total", expressions.TypeNumber).
+			SetVariable("order.items[0]", expressions.TypeString).
+			SetVariable("data", expressions.TypeAny).
+			SetFunction("upper", &expressions.Signature{
+				Params: []expressions.Type{expressions.TypeString},
+				Result: expressions.TypeString,
+			})
+
+		check := func(s string) error {
+			expr, err := expressions.Compile(s)
+			Expect(err).To(BeNil())
+			return expressions.Check(expr, schema)
+		}
+
+		typeErrors := func(err error) []*expressions.TypeError {
+			var checkErr *expressions.CheckError
+			Expect(errors.As(err, &checkErr)).To(BeTrue())
+			return checkErr.Errors
+		}
+
+		g.It("should accept valid expressions", func() {
+			for _, s := range []string{
+				"a * 2 + sqrt(a, 3) > 1 && !active",
+				"name == \"x\" || order.total >= 10",
+				"upper(order.items[0]) == name",
+				"data.anything[1].other + 1",
+				"if(active, name, a)",
+				"name[0] == \"j\"",
+				"(active ? a : 1) + 1",
+				"upper(a > 1 ? name : \"x\")",
+				"name + \" \" + upper(name) > 'a'",
+				"data + \"x\" == data + 1",
+				"len(trim(name)) > 2 && contains(lower(name), \"j\")",
+				"substr(name, 1, a) + format(\"{0} {1}\", a, active) + join(split(name, \",\"), \"-\")",
+			} {
+				Expect(check(s)).To(BeNil(), s)
+			}
+		})
+
+		g.It("should report operands of the wrong type", func() {
+			errs := typeErrors(check("a + name * 2"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(Equal("A number was expected, got string."))
+			Expect(errs[0].Span.Text()).To(Equal("name"))
+			Expect(errs[0].Span.Column).To(Equal(4))
+
+			errs = typeErrors(check("active > 1"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Span.Text()).To(Equal("active"))
+		})
+
+		g.It("should report the results of nested expressions", func() {
+			errs := typeErrors(check("(a > 1) * 2"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(Equal("A number was expected, got bool."))
+			Expect(errs[0].Span.Text()).To(Equal("a > 1"))
+
+			errs = typeErrors(check("upper(name) - 1"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(Equal("A number was expected, got string."))
+		})
+
+		g.It("should report variables that are not declared", func() {
+			errs := typeErrors(check("a + b"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(Equal("The variable 'b' is not declared."))
+			Expect(fmt.Sprint(errs[0])).To(Equal("The variable 'b' is not declared. (`b` at 1:4)"))
+		})
+
+		g.It("should report variables used as indexes once", func() {
+			errs := typeErrors(check("name[b]"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(Equal("The variable 'b' is not declared."))
+
+			errs = typeErrors(check("name[active]"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(Equal("A number was expected, got bool."))
+		})
+
+		g.It("should report members of values that have none", func() {
+			errs := typeErrors(check("a.b + 1"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(Equal("Cannot access members of number."))
+		})
+
+		g.It("should report unknown functions and wrong arities", func() {
+			errs := typeErrors(check("slug(name) == upper(name, 2)"))
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Message).To(Equal("The function 'slug' is not defined."))
+			Expect(errs[1].Message).To(Equal("'upper' expects 1 parameters."))
+
+			errs = typeErrors(check("atan2(a)"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(Equal("'atan2' expects 2 parameters."))
+
+			errs = typeErrors(check("substr(name) + name"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(Equal("'substr' expects 2 to 3 parameters."))
+
+			errs = typeErrors(check("sqrt(1, 2, 3) > 1 && substr(name, 1, 2, 3) == padLeft(name, 1, \"0\", \"0\")"))
+			Expect(errs).To(HaveLen(3))
+			Expect(errs[0].Message).To(Equal("'sqrt' expects 1 to 2 parameters."))
+			Expect(errs[1].Message).To(Equal("'substr' expects 2 to 3 parameters."))
+			Expect(errs[2].Message).To(Equal("'padLeft' expects 2 to 3 parameters."))
+
+			Expect(check("format(name, a, a, name) + name")).To(BeNil())
+		})
+
+		g.It("should check the type of parameters", func() {
+			errs := typeErrors(check("sqrt(a, name)"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Span.Text()).To(Equal("name"))
+
+			errs = typeErrors(check("padLeft(name, name, a) > 1"))
+			Expect(errs).To(HaveLen(3))
+			Expect(errs[0].Message).To(Equal("A number was expected, got string."))
+		})
+
+		g.It("should report all the errors", func() {
+			err := check("a + name > missing && cos(active)")
+			Expect(typeErrors(err)).To(HaveLen(3))
+			Expect(fmt.Sprint(err)).To(Equal("A number was expected, got string. (`name` at 1:4)\n" +
+				"The variable 'missing' is not declared. (`missing` at 1:11)\n" +
+				"A number was expected, got bool. (`active` at 1:26)"))
+		})
+
+		g.It("should type conditionals by their branches", func() {
+			errs := typeErrors(check("(active ? name : \"x\") * 2"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(Equal("A number was expected, got string."))
+			Expect(check("(active ? name : a) + 1")).To(BeNil())
+			errs = typeErrors(check("missing ? a : name * 2"))
+			Expect(errs).To(HaveLen(2))
+		})
+
+		g.It("should check concatenations and comparisons of strings", func() {
+			errs := typeErrors(check("name + a"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Message).To(Equal("A string was expected, got number."))
+			Expect(errs[0].Span.Text()).To(Equal("a"))
+
+			errs = typeErrors(check("name + name - name"))
+			Expect(errs).To(HaveLen(3))
+			Expect(errs[0].Message).To(Equal("A number was expected, got string."))
+
+			errs = typeErrors(check("name < a"))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Span.Text()).To(Equal("a"))
+		})
+
+		g.It("should check expressions built by hand", func() {
+			expr := expressions.NewExpressionMultiple()
+			expr.Add("", expressions.NewExpressionValue(1))
+			expr.Add("+", expressions.NewExpressionValue("x"))
+			err := expressions.Check(expr, expressions.NewSchema())
+			Expect(typeErrors(err)).To(HaveLen(1))
+			Expect(fmt.Sprint(err)).To(Equal("A number was expected, got string."))
+		})
+	})
+}
@@ -1,17 +1,22 @@
 package expressions
 
-type Context interface {
-	SetAccumulated(e float64)
-	Accumulated() float64
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
 
+type Context interface {
 	Resolver() Resolver
 	Functions() Functions
 }
 
 type BaseContext struct {
-	accumulated float64
-	resolver    Resolver
-	functions   Functions
+	resolver  Resolver
+	functions Functions
+	epsilon   float64
+	limits    Limits
 }
 
 func NewContext(resolver Resolver, functions Functions) *BaseContext {
@@ -21,14 +26,6 @@ func NewContext(resolver Resolver, functions Functions) *BaseContext {
 	}
 }
 
-func (ctx *BaseContext) SetAccumulated(e float64) {
-	ctx.accumulated = e
-}
-
-func (ctx *BaseContext) Accumulated() float64 {
-	return ctx.accumulated
-}
-
 func (ctx *BaseContext) Resolver() Resolver {
 	return ctx.resolver
 }
@@ -36,3 +33,172 @@ func (ctx *BaseContext) Resolver() Resolver {
 func (ctx *BaseContext) Functions() Functions {
 	return ctx.functions
 }
+
+func (ctx *BaseContext) Epsilon() float64 {
+	return ctx.epsilon
+}
+
+// SetEpsilon makes `==` and `!=` consider floats equal when their difference
+// is not greater than epsilon. Zero, the default, compares floats exactly.
+func (ctx *BaseContext) SetEpsilon(epsilon float64) *BaseContext {
+	ctx.epsilon = epsilon
+	return ctx
+}
+
+func (ctx *BaseContext) Limits() Limits {
+	return ctx.limits
+}
+
+// SetLimits bounds the work of solving expressions with this context.
+func (ctx *BaseContext) SetLimits(limits Limits) *BaseContext {
+	ctx.limits = limits
+	return ctx
+}
+
+// epsilonOf returns the epsilon of contexts that have one, like BaseContext,
+// and zero for the others.
+func epsilonOf(c Context) float64 {
+	if s, ok := c.(*solveContext); ok {
+		c = s.Context
+	}
+	if e, ok := c.(interface{ Epsilon() float64 }); ok {
+		return e.Epsilon()
+	}
+	return 0
+}
+
+// ContextResolver is implemented by resolvers that stop resolving when the
+// context.Context given to SolveContext is done.
+type ContextResolver interface {
+	Resolver
+	ResolveContext(ctx context.Context, name string) (interface{}, error)
+}
+
+// Solve solves an expression within the limits of the context, when it has
+// them.
+func Solve(expression Expression, c Context) (interface{}, error) {
+	return SolveContext(context.Background(), expression, c)
+}
+
+// SolveContext is like Solve but stops when ctx is done, in which case the
+// error of ctx is returned. ctx is checked between the evaluation of the
+// nodes, passed to resolvers that implement ContextResolver and available to
+// functions through ContextOf.
+func SolveContext(ctx context.Context, expression Expression, c Context) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s := &solveContext{
+		Context: c,
+		ctx:     ctx,
+	}
+	if limited, ok := c.(interface{ Limits() Limits }); ok {
+		s.limits = limited.Limits()
+	}
+	return expression.Solve(s)
+}
+
+// ContextOf returns the context.Context an expression is being solved with,
+// or context.Background() when it is not solved by SolveContext.
+func ContextOf(c Context) context.Context {
+	if s, ok := c.(*solveContext); ok {
+		return s.ctx
+	}
+	return context.Background()
+}
+
+// solveContext keeps the state of a call to SolveContext. It is not shared
+// between calls, so it is not synchronized.
+type solveContext struct {
+	Context
+	ctx       context.Context
+	limits    Limits
+	steps     int
+	callDepth int
+}
+
+// check returns the error of ctx when it is done and counts the given number
+// of steps.
+func (s *solveContext) check(steps int) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if steps > 0 {
+		s.steps += steps
+		if s.limits.MaxSteps > 0 && s.steps > s.limits.MaxSteps {
+			return &LimitExceededError{Limit: LimitSteps, Max: s.limits.MaxSteps}
+		}
+	}
+	return nil
+}
+
+func (s *solveContext) checkLength(v interface{}) error {
+	if s.limits.MaxLength > 0 && lengthOf(v) > s.limits.MaxLength {
+		return &LimitExceededError{Limit: LimitLength, Max: s.limits.MaxLength}
+	}
+	return nil
+}
+
+// limited returns the state of a call to SolveContext for contexts that have
+// limits, so expressions solved by their Solve method are limited as well.
+func limited(c Context) Context {
+	if _, ok := c.(*solveContext); ok {
+		return c
+	}
+	if l, ok := c.(interface{ Limits() Limits }); ok && l.Limits() != (Limits{}) {
+		return &solveContext{
+			Context: c,
+			ctx:     context.Background(),
+			limits:  l.Limits(),
+		}
+	}
+	return c
+}
+
+// checkpoint is called by the nodes before they are evaluated, to stop when
+// the context.Context of SolveContext is done or the steps are exceeded. The
+// nodes solve their operands with the context it returns.
+func checkpoint(c Context) (Context, error) {
+	c = limited(c)
+	if s, ok := c.(*solveContext); ok {
+		return s, s.check(1)
+	}
+	return c, nil
+}
+
+// checkLength fails when a value produced while solving is longer than the
+// limits allow.
+func checkLength(c Context, v interface{}) error {
+	if s, ok := c.(*solveContext); ok {
+		return s.checkLength(v)
+	}
+	return nil
+}
+
+// checkSize fails when a result made of count copies of size bytes, which is
+// about to be built, would be longer than the limits allow.
+func checkSize(c Context, name string, count int, size int) error {
+	if size > 0 && count > math.MaxInt32/size {
+		return errors.New(fmt.Sprintf("The result of '%s' is too long.", name))
+	}
+	if s, ok := c.(*solveContext); ok && s.limits.MaxLength > 0 && count*size > s.limits.MaxLength {
+		return &LimitExceededError{Limit: LimitLength, Max: s.limits.MaxLength}
+	}
+	return nil
+}
+
+func resolve(c Context, name string) (interface{}, error) {
+	var (
+		v   interface{}
+		err error
+	)
+	if resolver, ok := c.Resolver().(ContextResolver); ok {
+		v, err = resolver.ResolveContext(ContextOf(c), name)
+	} else {
+		v, err = c.Resolver().Resolve(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v, checkLength(c, v)
+}
@@ -0,0 +1,122 @@
+package expressions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"github.com/jamillosantos/go-expressions"
+	. "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+type contextKey string
+
+type tenantResolver struct{}
+
+func (tenantResolver) Resolve(name string) (interface{}, error) {
+	return nil, errors.New("ResolveContext should have been called")
+}
+
+func (tenantResolver) ResolveContext(ctx context.Context, name string) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return ctx.Value(contextKey("tenant")).(string) + "." + name, nil
+}
+
+func TestSolveContext(t *testing.T) {
+	g := Goblin(t)
+
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("SolveContext", func() {
+		var cancel context.CancelFunc
+		functions := expressions.NewDefaultFunctionRegistry().
+			MustRegister("cancel", func(v bool) bool {
+				cancel()
+				return v
+			}).
+			MustRegister("wait", func(ctx context.Context, ms int) (bool, error) {
+				select {
+				case <-ctx.Done():
+					return false, ctx.Err()
+				case <-time.After(time.Duration(ms) * time.Millisecond):
+					return true, nil
+				}
+			})
+		resolver := expressions.NewMapResolver(map[string]interface{}{
+			"a": int64(2),
+		})
+		ctx := expressions.NewContext(resolver, functions)
+
+		solvers := map[string]func(expressions.Expression) expressions.Expression{
+			"tree": func(e expressions.Expression) expressions.Expression {
+				return e
+			},
+			"program": func(e expressions.Expression) expressions.Expression {
+				return expressions.NewProgram(e)
+			},
+		}
+
+		g.It("should solve when the context is not done", func() {
+			expr, err := expressions.Compile("a * 3 + if(wait(1), 1, 0)")
+			Expect(err).To(BeNil())
+			v, err := expressions.SolveContext(context.Background(), expr, ctx)
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(int64(7)))
+		})
+
+		g.It("should not solve with a context already done", func() {
+			expr, err := expressions.Compile("cancel(true)")
+			Expect(err).To(BeNil())
+			goCtx, c := context.WithCancel(context.Background())
+			c()
+			cancel = func() { g.Fail("the function should not be called") }
+			_, err = expressions.SolveContext(goCtx, expr, ctx)
+			Expect(err).To(Equal(context.Canceled))
+		})
+
+		g.It("should stop between the evaluation of nodes", func() {
+			for name, solver := range solvers {
+				expr, err := expressions.Compile("cancel(true) && a > 1")
+				Expect(err).To(BeNil())
+				var goCtx context.Context
+				goCtx, cancel = context.WithCancel(context.Background())
+				_, err = expressions.SolveContext(goCtx, solver(expr), ctx)
+				Expect(errors.Is(err, context.Canceled)).To(BeTrue(), name)
+			}
+		})
+
+		g.It("should pass the context to functions", func() {
+			for name, solver := range solvers {
+				expr, err := expressions.Compile("1 + if(wait(5000), 1, 0)")
+				Expect(err).To(BeNil())
+				goCtx, c := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				start := time.Now()
+				_, err = expressions.SolveContext(goCtx, solver(expr), ctx)
+				c()
+				Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue(), name)
+				Expect(time.Since(start) < time.Second).To(BeTrue(), name)
+			}
+		})
+
+		g.It("should pass the context to resolvers", func() {
+			expr, err := expressions.Compile("customer")
+			Expect(err).To(BeNil())
+			goCtx := context.WithValue(context.Background(), contextKey("tenant"), "acme")
+			v, err := expressions.SolveContext(goCtx, expr, expressions.NewContext(tenantResolver{}, nil))
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal("acme.customer"))
+		})
+
+		g.It("should give functions a background context when solving without one", func() {
+			Expect(expressions.ContextOf(ctx)).To(Equal(context.Background()))
+			expr, err := expressions.Compile("wait(1)")
+			Expect(err).To(BeNil())
+			v, err := expr.Solve(ctx)
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(true))
+		})
+	})
+}
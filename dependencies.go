@@ -0,0 +1,93 @@
+package expressions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Dependencies are the variables and functions an expression uses.
+//
+// Variables are listed with the members and constant indexes accessed on
+// them, like `order.customer.name` or `items[0].price`. A path is cut before
+// an index that is only known when solving, so `items[n].price` depends on
+// `items` and `n`.
+type Dependencies struct {
+	Variables []string
+	Functions []string
+}
+
+// FindDependencies returns the dependencies of an expression, sorted and
+// without repetitions. Names replaced by constants when compiling are not
+// variables.
+func FindDependencies(expression Expression) *Dependencies {
+	variables := make(map[string]bool)
+	functions := make(map[string]bool)
+	Inspect(expression, func(e Expression) bool {
+		switch ee := e.(type) {
+		case *ExpressionField, *ExpressionMember, *ExpressionIndex:
+			if path, ok := variablePath(e); ok {
+				variables[path] = true
+				return false
+			}
+		case *ExpressionFunction:
+			functions[ee.name] = true
+		}
+		return true
+	})
+	return &Dependencies{
+		Variables: sortedKeys(variables),
+		Functions: sortedKeys(functions),
+	}
+}
+
+// HasVariable reports whether the expression uses the variable name, alone or
+// as the root of a path.
+func (d *Dependencies) HasVariable(name string) bool {
+	for _, v := range d.Variables {
+		if v == name || strings.HasPrefix(v, name+".") || strings.HasPrefix(v, name+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dependencies) HasFunction(name string) bool {
+	for _, f := range d.Functions {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// variablePath renders the path of fields accessed through members and
+// constant indexes. It fails for anything else.
+func variablePath(e Expression) (string, bool) {
+	switch ee := e.(type) {
+	case *ExpressionField:
+		return ee.field, true
+	case *ExpressionMember:
+		if path, ok := variablePath(ee.expression); ok {
+			return path + "." + ee.name, true
+		}
+	case *ExpressionIndex:
+		if index, ok := ee.index.(*ExpressionValue); ok {
+			if path, ok := variablePath(ee.expression); ok {
+				return fmt.Sprintf("%s[%#v]", path, index.value), true
+			}
+		}
+	case *ExpressionBrackets:
+		return variablePath(ee.inner)
+	}
+	return "", false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	r := make([]string, 0, len(m))
+	for k := range m {
+		r = append(r, k)
+	}
+	sort.Strings(r)
+	return r
+}
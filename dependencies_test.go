@@ -0,0 +1,67 @@
+package expressions_test
+
+import (
+	"testing"
+	"github.com/jamillosantos/go-expressions"
+	. "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestDependencies(t *testing.T) {
+	g := Goblin(t)
+
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("FindDependencies", func() {
+		g.It("should find variables and functions", func() {
+			expr, err := expressions.Compile("price * quantity + sqrt(tax) - if(discount > price, discount, cos(0))")
+			Expect(err).To(BeNil())
+			deps := expressions.FindDependencies(expr)
+			Expect(deps.Variables).To(Equal([]string{"discount", "price", "quantity", "tax"}))
+			Expect(deps.Functions).To(Equal([]string{"cos", "if", "sqrt"}))
+		})
+
+		g.It("should find paths of members and constant indexes", func() {
+			expr, err := expressions.Compile("order.customer.name == \"Ann\" && (order.items)[0].price > 1 && map[\"key\"]")
+			Expect(err).To(BeNil())
+			Expect(expressions.FindDependencies(expr).Variables).To(Equal([]string{"map[\"key\"]", "order.customer.name", "order.items[0].price"}))
+		})
+
+		g.It("should cut paths at indexes solved at runtime", func() {
+			expr, err := expressions.Compile("items[n + 1].price + f(a).b")
+			Expect(err).To(BeNil())
+			deps := expressions.FindDependencies(expr)
+			Expect(deps.Variables).To(Equal([]string{"a", "items", "n"}))
+			Expect(deps.Functions).To(Equal([]string{"f"}))
+		})
+
+		g.It("should not list constants", func() {
+			compiler := expressions.NewCompiler()
+			compiler.SetConstant("rate", 0.5)
+			expr, err := compiler.Compile("rate * pi * amount")
+			Expect(err).To(BeNil())
+			Expect(expressions.FindDependencies(expr).Variables).To(Equal([]string{"amount"}))
+		})
+
+		g.It("should tell whether a variable or function is used", func() {
+			expr, err := expressions.Compile("order.customer.name + count(items)")
+			Expect(err).To(BeNil())
+			deps := expressions.FindDependencies(expr)
+			Expect(deps.HasVariable("order")).To(BeTrue())
+			Expect(deps.HasVariable("order.customer")).To(BeTrue())
+			Expect(deps.HasVariable("order.customer.name")).To(BeTrue())
+			Expect(deps.HasVariable("order.cust")).To(BeFalse())
+			Expect(deps.HasVariable("items")).To(BeTrue())
+			Expect(deps.HasFunction("count")).To(BeTrue())
+			Expect(deps.HasFunction("sqrt")).To(BeFalse())
+		})
+
+		g.It("should find nothing in constant expressions", func() {
+			expr, err := expressions.Compile("1 + 2")
+			Expect(err).To(BeNil())
+			deps := expressions.FindDependencies(expr)
+			Expect(deps.Variables).To(BeEmpty())
+			Expect(deps.Functions).To(BeEmpty())
+		})
+	})
+}
@@ -0,0 +1,344 @@
+package expressions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// signedAtomStart and identifierStart are the tokens that can start a
+// signedAtom and an identifier of Expression.g4.
+var (
+	signedAtomStart = []tokenKind{tokenLParen, tokenPlus, tokenMinus, tokenNot, tokenPI, tokenEuler, tokenI, tokenTrue, tokenFalse, tokenNull, tokenVariable, tokenQuotedString, tokenScientificNumber}
+	identifierStart = []tokenKind{tokenPI, tokenEuler, tokenI, tokenTrue, tokenFalse, tokenNull, tokenVariable}
+)
+
+// binaryLevels lists the operators of the binary rules of Expression.g4, from
+// the lowest to the highest precedence.
+var binaryLevels = [][]tokenKind{
+	{tokenOr},
+	{tokenXor},
+	{tokenAnd},
+	{tokenEQ, tokenNotEQ},
+	{tokenGT, tokenLT, tokenGTE, tokenLTE},
+	{tokenPlus, tokenMinus},
+	{tokenTimes, tokenDiv, tokenMod},
+	{tokenPow},
+}
+
+const (
+	levelEquality = 3
+	levelAdditive = 5
+)
+
+// descentParser is a recursive-descent parser for the language described by
+// Expression.g4. It builds the same trees the parser generated by ANTLR builds,
+// spans included, as the tests check, and recovers from errors the way ANTLR does
+// for the common cases: unexpected tokens are skipped when the next one fits
+// and missing tokens are reported and assumed.
+type descentParser struct {
+	compiler  *Compiler
+	source    []rune
+	tokens    []token
+	pos       int
+	errors    []*SyntaxError
+	lastError int
+	depth     int
+	limit     *LimitExceededError
+}
+
+func newDescentParser(compiler *Compiler, source string) *descentParser {
+	p := &descentParser{
+		compiler:  compiler,
+		source:    []rune(source),
+		lastError: -1,
+	}
+	p.tokens, p.errors = newLexer(p.source).scan()
+	return p
+}
+
+func (p *descentParser) parse() Expression {
+	r := p.expression()
+	if t := p.current(); t.kind != tokenEOF && p.lastError != p.pos {
+		p.fail(t, fmt.Sprintf("extraneous input %s expecting <EOF>", t.display()), []string{"<EOF>"})
+	}
+	return r
+}
+
+func (p *descentParser) current() *token {
+	return &p.tokens[p.pos]
+}
+
+func (p *descentParser) next() *token {
+	if p.pos+1 < len(p.tokens) {
+		return &p.tokens[p.pos+1]
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *descentParser) consume() *token {
+	t := &p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *descentParser) fail(t *token, message string, expected []string) {
+	if p.limit != nil {
+		return
+	}
+	err := &SyntaxError{
+		Line:      t.line,
+		Column:    t.column,
+		Offending: t.text,
+		Expected:  expected,
+		Message:   message,
+	}
+	length := t.end - t.start
+	if t.kind == tokenEOF {
+		length = 1
+	}
+	err.Snippet = snippet(string(p.source), t.line, t.column, length)
+	p.errors = append(p.errors, err)
+	p.lastError = p.pos
+}
+
+// match consumes a token of the given kind, reporting it as missing when it is
+// not found. expected lists all the tokens valid at this point.
+func (p *descentParser) match(kind tokenKind, expected ...tokenKind) {
+	if len(expected) == 0 {
+		expected = []tokenKind{kind}
+	}
+	switch {
+	case p.current().kind == kind:
+	case p.next().kind == kind:
+		p.fail(p.current(), fmt.Sprintf("extraneous input %s expecting %s", p.current().display(), displayKinds(expected)), namesOf(expected))
+		p.consume()
+	default:
+		p.fail(p.current(), fmt.Sprintf("missing %s at %s", displayKinds(expected), p.current().display()), namesOf(expected))
+		return
+	}
+	p.consume()
+}
+
+// span returns the span from the token at start to the last token consumed.
+func (p *descentParser) span(start int) *Span {
+	first, last := &p.tokens[start], &p.tokens[start]
+	if p.pos > start {
+		last = &p.tokens[p.pos-1]
+	}
+	return &Span{
+		Start:  first.start,
+		End:    last.end,
+		Line:   first.line,
+		Column: first.column,
+		source: p.source,
+	}
+}
+
+func (p *descentParser) setSpan(e Expression, start int) Expression {
+	if s, ok := e.(Spanned); ok {
+		s.SetSpan(p.span(start))
+	}
+	return e
+}
+
+// expression parses the conditional operator, whose branches are nested
+// expressions.
+func (p *descentParser) expression() Expression {
+	start := p.pos
+	r := p.binary(0)
+	if p.current().kind != tokenQuestion {
+		return r
+	}
+	p.consume()
+	defer p.unnest()
+	if !p.nest() {
+		return r
+	}
+	then := p.expression()
+	// Like ANTLR, the colon is only assumed missing when what follows can be
+	// the expression after it, and it is not reported again on a token that
+	// already failed.
+	if t := p.current(); t.kind != tokenColon && p.next().kind != tokenColon && !isKind(t.kind, signedAtomStart) {
+		if p.lastError != p.pos {
+			p.fail(t, fmt.Sprintf("mismatched input %s expecting %s", t.display(), displayKinds([]tokenKind{tokenColon})), namesOf([]tokenKind{tokenColon}))
+		}
+		return p.setSpan(NewExpressionConditional(r, then, NewExpressionValue(nil)), start)
+	}
+	p.match(tokenColon)
+	otherwise := p.expression()
+	return p.setSpan(NewExpressionConditional(r, then, otherwise), start)
+}
+
+// nest enters a nested expression. When the nesting exceeds the limit of the
+// compiler, the remaining tokens are skipped, so the parser returns without
+// going deeper. unnest must be called even when it fails.
+func (p *descentParser) nest() bool {
+	p.depth++
+	if max := p.compiler.maxDepth; max > 0 && p.depth > max {
+		if p.limit == nil {
+			p.limit = &LimitExceededError{Limit: LimitDepth, Max: max}
+			p.pos = len(p.tokens) - 1
+		}
+		return false
+	}
+	return true
+}
+
+func (p *descentParser) unnest() {
+	p.depth--
+}
+
+// binary parses the rule of a precedence level: operands of the next level
+// separated by the operators of this one.
+func (p *descentParser) binary(level int) Expression {
+	if level == len(binaryLevels) {
+		return p.signedAtom()
+	}
+	start := p.pos
+	r := p.binary(level + 1)
+	if !isKind(p.current().kind, binaryLevels[level]) {
+		return r
+	}
+	var multiple *ExpressionMultiple
+	if level >= levelAdditive {
+		multiple = NewExpressionMultiple()
+		multiple.Add("", r)
+	}
+	for isKind(p.current().kind, binaryLevels[level]) {
+		operator := p.consume().text
+		operand := p.binary(level + 1)
+		switch {
+		case multiple != nil:
+			multiple.Add(operator, operand)
+		case level >= levelEquality:
+			r = p.setSpan(NewExpressionBinary(r, operator, operand), start)
+		default:
+			r = p.setSpan(NewExpressionLogical(r, operator, operand), start)
+		}
+	}
+	if multiple != nil {
+		return p.setSpan(multiple, start)
+	}
+	return r
+}
+
+// signedAtom is where the parser nests, through unary operators, parentheses,
+// calls and indexes, along with the branches of conditionals.
+func (p *descentParser) signedAtom() Expression {
+	defer p.unnest()
+	if !p.nest() {
+		return NewExpressionValue(nil)
+	}
+	start := p.pos
+	t := p.current()
+	if !isKind(t.kind, signedAtomStart) {
+		if !isKind(p.next().kind, signedAtomStart) {
+			p.fail(t, fmt.Sprintf("mismatched input %s expecting %s", t.display(), displayKinds(signedAtomStart)), namesOf(signedAtomStart))
+			return NewExpressionValue(nil)
+		}
+		p.fail(t, fmt.Sprintf("extraneous input %s expecting %s", t.display(), displayKinds(signedAtomStart)), namesOf(signedAtomStart))
+		p.consume()
+		start = p.pos
+		t = p.current()
+	}
+	switch t.kind {
+	case tokenPlus, tokenMinus, tokenNot:
+		p.consume()
+		operand := p.signedAtom()
+		if t.kind == tokenNot {
+			return p.setSpan(NewExpressionNot(operand), start)
+		}
+		expr := NewExpressionMultiple()
+		expr.Add("", NewExpressionValue(0))
+		expr.Add(t.text, operand)
+		return p.setSpan(expr, start)
+	}
+	var r Expression
+	if t.kind == tokenVariable && p.next().kind == tokenLParen {
+		r = p.function()
+	} else {
+		r = p.atom()
+	}
+	for {
+		switch p.current().kind {
+		case tokenPoint:
+			p.consume()
+			name := ""
+			if isKind(p.current().kind, identifierStart) {
+				name = p.consume().text
+			} else {
+				p.fail(p.current(), fmt.Sprintf("missing %s at %s", displayKinds(identifierStart), p.current().display()), namesOf(identifierStart))
+			}
+			r = p.setSpan(NewExpressionMember(r, name), start)
+		case tokenLBracket:
+			p.consume()
+			index := p.expression()
+			p.match(tokenRBracket)
+			r = p.setSpan(NewExpressionIndex(r, index), start)
+		default:
+			return r
+		}
+	}
+}
+
+func (p *descentParser) function() Expression {
+	start := p.pos
+	name := p.consume().text
+	p.consume()
+	params := []Expression{p.expression()}
+	for p.current().kind == tokenComma {
+		p.consume()
+		params = append(params, p.expression())
+	}
+	p.match(tokenRParen, tokenRParen, tokenComma)
+	return p.setSpan(NewExpressionFunction(name, params...), start)
+}
+
+func (p *descentParser) atom() Expression {
+	start := p.pos
+	t := p.consume()
+	var r Expression
+	switch t.kind {
+	case tokenLParen:
+		r = p.expression()
+		p.match(tokenRParen)
+		return r
+	case tokenScientificNumber:
+		r = NewExpressionValue(numberValue(t.text))
+	case tokenVariable, tokenPI, tokenEuler, tokenI:
+		r = p.compiler.variable(t.text)
+	case tokenQuotedString:
+		r = NewExpressionValue(stringValue(t.text))
+	case tokenTrue, tokenFalse:
+		r = NewExpressionValue(t.kind == tokenTrue)
+	case tokenNull:
+		r = NewExpressionValue(nil)
+	}
+	return p.setSpan(r, start)
+}
+
+func isKind(kind tokenKind, kinds []tokenKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func namesOf(kinds []tokenKind) []string {
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = k.String()
+	}
+	return names
+}
+
+func displayKinds(kinds []tokenKind) string {
+	if len(kinds) == 1 {
+		return kinds[0].String()
+	}
+	return "{" + strings.Join(namesOf(kinds), ", ") + "}"
+}
@@ -0,0 +1,124 @@
+package expressions
+
+import (
+	"math"
+	"math/big"
+	"math/cmplx"
+	"reflect"
+)
+
+// valuesEqual compares two solved values. Numbers are compared by value across
+// all Go numeric kinds, complex numbers included, slices, arrays and maps are
+// compared element by element and nil pointers, maps and slices are equal to
+// nil. When epsilon is greater than zero, floats closer than epsilon are
+// considered equal.
+func valuesEqual(left, right interface{}, epsilon float64) bool {
+	lNil, rNil := isNil(left), isNil(right)
+	if lNil || rNil {
+		return lNil && rNil
+	}
+	if isComplex(left) || isComplex(right) {
+		l, lok := toComplex(left)
+		r, rok := toComplex(right)
+		return lok && rok && (l == r || cmplx.Abs(l-r) <= epsilon)
+	}
+	if l, ok := normalizeNumber(left); ok {
+		r, ok := normalizeNumber(right)
+		if !ok {
+			return false
+		}
+		if epsilon > 0 && (isFloat(l) || isFloat(r)) {
+			lf, _ := toFloat64(l)
+			rf, _ := toFloat64(r)
+			return lf == rf || math.Abs(lf-rf) <= epsilon
+		}
+		return numbersEqual(l, r)
+	}
+	switch l := left.(type) {
+	case string:
+		r, ok := right.(string)
+		return ok && l == r
+	case bool:
+		r, ok := right.(bool)
+		return ok && l == r
+	}
+
+	lv, rv := reflect.ValueOf(left), reflect.ValueOf(right)
+	switch lv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return false
+		}
+		if lv.Len() != rv.Len() {
+			return false
+		}
+		for i := 0; i < lv.Len(); i++ {
+			if !valuesEqual(lv.Index(i).Interface(), rv.Index(i).Interface(), epsilon) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if rv.Kind() != reflect.Map || lv.Len() != rv.Len() {
+			return false
+		}
+		for _, key := range lv.MapKeys() {
+			rValue, ok := mapLookup(rv, key, epsilon)
+			if !ok || !valuesEqual(lv.MapIndex(key).Interface(), rValue.Interface(), epsilon) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+// numbersEqual compares two normalized numbers without the rounding that
+// happens when large integers are converted to float64.
+func numbersEqual(l, r interface{}) bool {
+	lf, lFloat := l.(float64)
+	rf, rFloat := r.(float64)
+	switch {
+	case lFloat && rFloat:
+		return lf == rf
+	case lFloat:
+		return floatEqualsInteger(lf, r)
+	case rFloat:
+		return floatEqualsInteger(rf, l)
+	}
+	c, _ := compareNumbers(l, r)
+	return c == 0
+}
+
+func floatEqualsInteger(f float64, i interface{}) bool {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+		return false
+	}
+	return new(big.Float).SetFloat64(f).Cmp(new(big.Float).SetInt(toBig(i))) == 0
+}
+
+func mapLookup(m reflect.Value, key reflect.Value, epsilon float64) (reflect.Value, bool) {
+	if key.Type().AssignableTo(m.Type().Key()) {
+		if v := m.MapIndex(key); v.IsValid() {
+			return v, true
+		}
+	}
+	for _, k := range m.MapKeys() {
+		if valuesEqual(key.Interface(), k.Interface(), epsilon) {
+			return m.MapIndex(k), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return rv.IsNil()
+	}
+	return false
+}
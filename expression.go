@@ -1,9 +1,9 @@
 package expressions
 
 import (
-	"math"
 	"fmt"
 	"errors"
+	"strings"
 )
 
 type WrongTypeError struct {
@@ -25,7 +25,10 @@ type Expression interface {
 }
 
 type ExpressionValue struct {
+	spanned
 	value interface{}
+	// name is the constant the value was compiled from, if any.
+	name string
 }
 
 func NewExpressionValue(value interface{}) *ExpressionValue {
@@ -34,11 +37,16 @@ func NewExpressionValue(value interface{}) *ExpressionValue {
 	}
 }
 
+func (e *ExpressionValue) Value() interface{} {
+	return e.value
+}
+
 func (e *ExpressionValue) Solve(ctx Context) (interface{}, error) {
 	return e.value, nil
 }
 
 type ExpressionField struct {
+	spanned
 	field string
 }
 
@@ -48,11 +56,135 @@ func NewExpressionField(field string) *ExpressionField {
 	}
 }
 
+func (e *ExpressionField) Field() string {
+	return e.field
+}
+
 func (e *ExpressionField) Solve(ctx Context) (interface{}, error) {
-	return ctx.Resolver().Resolve(e.field)
+	ctx, err := checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v, err := resolve(ctx, e.field)
+	if err != nil {
+		return nil, e.wrap("field", err)
+	}
+	return v, nil
+}
+
+type ExpressionMember struct {
+	spanned
+	expression Expression
+	name       string
+}
+
+func NewExpressionMember(expression Expression, name string) *ExpressionMember {
+	return &ExpressionMember{
+		expression: expression,
+		name:       name,
+	}
+}
+
+func (e *ExpressionMember) Expression() Expression {
+	return e.expression
+}
+
+func (e *ExpressionMember) Name() string {
+	return e.name
+}
+
+func (e *ExpressionMember) Solve(ctx Context) (interface{}, error) {
+	ctx, err := checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v, err := e.expression.Solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.lookup(v)
+	if err != nil {
+		return nil, err
+	}
+	return r, checkLength(ctx, r)
+}
+
+func (e *ExpressionMember) lookup(v interface{}) (interface{}, error) {
+	r, err := lookupMember(v, e.name)
+	if err != nil {
+		return nil, e.wrap("member", NewMemberError(describePath(e.expression), "'"+e.name+"'", err.Error()), v)
+	}
+	return r, nil
+}
+
+type ExpressionIndex struct {
+	spanned
+	expression Expression
+	index      Expression
+}
+
+func NewExpressionIndex(expression Expression, index Expression) *ExpressionIndex {
+	return &ExpressionIndex{
+		expression: expression,
+		index:      index,
+	}
+}
+
+func (e *ExpressionIndex) Expression() Expression {
+	return e.expression
+}
+
+func (e *ExpressionIndex) Index() Expression {
+	return e.index
+}
+
+func (e *ExpressionIndex) Solve(ctx Context) (interface{}, error) {
+	ctx, err := checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v, err := e.expression.Solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i, err := e.index.Solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.lookup(v, i)
+	if err != nil {
+		return nil, err
+	}
+	return r, checkLength(ctx, r)
+}
+
+func (e *ExpressionIndex) lookup(v interface{}, i interface{}) (interface{}, error) {
+	r, err := lookupIndex(v, i)
+	if err != nil {
+		return nil, e.wrap("index", NewMemberError(describePath(e.expression), fmt.Sprintf("[%#v]", i), err.Error()), v, i)
+	}
+	return r, nil
+}
+
+// describePath renders fields, members and indexes the way they are written in
+// expressions, so errors can point to the path that failed.
+func describePath(e Expression) string {
+	switch ee := e.(type) {
+	case *ExpressionField:
+		return ee.field
+	case *ExpressionMember:
+		return describePath(ee.expression) + "." + ee.name
+	case *ExpressionIndex:
+		if v, ok := ee.index.(*ExpressionValue); ok {
+			return fmt.Sprintf("%s[%#v]", describePath(ee.expression), v.value)
+		}
+		return describePath(ee.expression) + "[...]"
+	}
+	return "the expression"
 }
 
 type ExpressionMultiple struct {
+	spanned
 	terms []*ExpressionMultiplePart
 }
 
@@ -60,43 +192,73 @@ func NewExpressionMultiple() *ExpressionMultiple {
 	return &ExpressionMultiple{}
 }
 
+// Terms returns a copy of the terms, which are combined from left to right.
+// The operator of the first term is empty.
+func (e *ExpressionMultiple) Terms() []*ExpressionMultiplePart {
+	return append([]*ExpressionMultiplePart(nil), e.terms...)
+}
+
 func (e *ExpressionMultiple) Solve(ctx Context) (interface{}, error) {
-	result := float64(0)
-	var r float64
+	ctx, err := checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{} = int64(0)
 	for i, p := range e.terms {
+		v, err := p.expression.Solve(ctx)
+		if err != nil {
+			return nil, err
+		}
 		if i == 0 {
-			rTemp, err := p.Solve(ctx)
-			if err != nil {
-				return nil, err
-			}
-			switch rr := rTemp.(type) {
-			case int:
-				r = float64(rr)
-			case float64:
-				r = rr
-			default:
-				return nil, NewWrongTypeError(rTemp)
-			}
-
-			result = r
-		} else {
-			ctx.SetAccumulated(result)
-			rTemp, err := p.Solve(ctx)
-			if err != nil {
-				return nil, err
-			}
-			switch rr := rTemp.(type) {
-			case int:
-				r = float64(rr)
-			case float64:
-				r = rr
-			}
-			result = r
+			result, err = e.first(v)
+		} else if result, err = e.combine(result, p.operator, v); err == nil {
+			err = checkLength(ctx, result)
+		}
+		if err != nil {
+			return nil, err
 		}
 	}
 	return result, nil
 }
 
+// first checks the value of the first term, which must be a number unless all
+// the terms are concatenated.
+func (e *ExpressionMultiple) first(v interface{}) (interface{}, error) {
+	if s, ok := v.(string); ok && concatenates(e.terms) {
+		return s, nil
+	}
+	if isComplex(v) {
+		return v, nil
+	}
+	r, ok := normalizeNumber(v)
+	if !ok {
+		return nil, e.wrap("arithmetic", NewWrongTypeError(v), v)
+	}
+	return r, nil
+}
+
+func (e *ExpressionMultiple) combine(accumulated interface{}, operator string, v interface{}) (interface{}, error) {
+	r, err := combine(accumulated, operator, v)
+	if err != nil {
+		return nil, e.wrap("arithmetic", err, accumulated, v)
+	}
+	return r, nil
+}
+
+// concatenates reports whether terms are strings concatenated: there are
+// several of them and all the operators are `+`.
+func concatenates(terms []*ExpressionMultiplePart) bool {
+	if len(terms) < 2 {
+		return false
+	}
+	for _, term := range terms[1:] {
+		if term.operator != "+" {
+			return false
+		}
+	}
+	return true
+}
+
 func (e *ExpressionMultiple) Add(operator string, exp Expression) {
 	e.terms = append(e.terms, &ExpressionMultiplePart{
 		operator:   operator,
@@ -116,75 +278,46 @@ func NewExpressionMultiplePart(operator string, expression Expression) *Expressi
 	}
 }
 
-func (e *ExpressionMultiplePart) Solve(ctx Context) (interface{}, error) {
-	accumulated := ctx.Accumulated()
+func (e *ExpressionMultiplePart) Operator() string {
+	return e.operator
+}
+
+func (e *ExpressionMultiplePart) Expression() Expression {
+	return e.expression
+}
+
+// Apply solves the part expression and combines it with the value accumulated
+// by the previous terms. The accumulated value is passed in, instead of being
+// stored anywhere, so the same tree can be solved concurrently.
+func (e *ExpressionMultiplePart) Apply(ctx Context, accumulated interface{}) (interface{}, error) {
 	v, err := e.expression.Solve(ctx)
 	if err != nil {
 		return v, err
 	}
-	switch e.operator {
+	return combine(accumulated, e.operator, v)
+}
+
+func combine(accumulated interface{}, operator string, v interface{}) (interface{}, error) {
+	switch operator {
 	case "":
 		return v, nil
 	case "+":
-		switch vv := v.(type) {
-		case int:
-			return accumulated + float64(vv), nil
-		case float64:
-			return accumulated + vv, nil
-		default:
-			return nil, NewWrongTypeError(v)
-		}
-	case "-":
-		switch vv := v.(type) {
-		case int:
-			return accumulated - float64(vv), nil
-		case float64:
-			return accumulated - vv, nil
-		default:
-			return nil, NewWrongTypeError(v)
-		}
-	case "*":
-		switch vv := v.(type) {
-		case int:
-			return accumulated * float64(vv), nil
-		case float64:
-			return accumulated * vv, nil
-		default:
-			return nil, NewWrongTypeError(v)
-		}
-	case "/":
-		switch vv := v.(type) {
-		case int:
-			return accumulated / float64(vv), nil
-		case float64:
-			return accumulated / vv, nil
-		default:
-			return nil, NewWrongTypeError(v)
-		}
-	case "%":
-		switch vv := v.(type) {
-		case int:
-			return int(accumulated+0.5) % vv, nil
-		case float64:
-			return int(accumulated+0.5) % int(vv+0.5), nil
-		default:
-			return nil, NewWrongTypeError(v)
-		}
-	case "^":
-		switch vv := v.(type) {
-		case int:
-			return math.Pow(accumulated, float64(vv)), nil
-		case float64:
-			return math.Pow(accumulated, vv), nil
-		default:
+		if s, ok := accumulated.(string); ok {
+			if r, ok := v.(string); ok {
+				return s + r, nil
+			}
 			return nil, NewWrongTypeError(v)
 		}
+		return arithmetic(accumulated, operator, v)
+	case "-", "*", "/", "%", "^":
+		return arithmetic(accumulated, operator, v)
 	default:
-		return nil, errors.New(fmt.Sprintf("The operator '%s' is not supported.", e.operator))
+		return nil, errors.New(fmt.Sprintf("The operator '%s' is not supported.", operator))
 	}
 }
 
 type ExpressionBinary struct {
+	spanned
 	left     Expression
 	operator string
 	right    Expression
@@ -198,7 +331,23 @@ func NewExpressionBinary(left Expression, operator string, right Expression) *Ex
 	}
 }
 
+func (e *ExpressionBinary) Left() Expression {
+	return e.left
+}
+
+func (e *ExpressionBinary) Operator() string {
+	return e.operator
+}
+
+func (e *ExpressionBinary) Right() Expression {
+	return e.right
+}
+
 func (e *ExpressionBinary) Solve(ctx Context) (interface{}, error) {
+	ctx, err := checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
 	rLeft, err := e.left.Solve(ctx)
 	if err != nil {
 		return nil, err
@@ -207,50 +356,207 @@ func (e *ExpressionBinary) Solve(ctx Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	return e.compare(ctx, rLeft, rRight)
+}
+
+func (e *ExpressionBinary) compare(ctx Context, rLeft interface{}, rRight interface{}) (interface{}, error) {
 	switch e.operator {
 	case ">", "<", ">=", "<=":
-		var (
-			left  float64
-			right float64
-		)
-		switch r := rLeft.(type) {
-		case int:
-			left = float64(r)
-		case float64:
-			left = r
-		default:
-			return nil, NewWrongTypeError(rLeft)
-		}
-		switch r := rRight.(type) {
-		case int:
-			right = float64(r)
-		case float64:
-			right = r
-		default:
-			return nil, NewWrongTypeError(rRight)
+		c, err := compareValues(rLeft, rRight)
+		if err != nil {
+			return nil, e.wrap("comparison", err, rLeft, rRight)
 		}
 		switch e.operator {
 		case ">":
-			return left > right, nil
+			return c == 1, nil
 		case "<":
-			return left < right, nil
+			return c == -1, nil
 		case ">=":
-			return left >= right, nil
+			return c == 0 || c == 1, nil
 		case "<=":
-			return left <= right, nil
+			return c == 0 || c == -1, nil
 		}
 	case "==", "!=":
 		switch e.operator {
 		case "==":
-			return rLeft == rRight, nil
+			return valuesEqual(rLeft, rRight, epsilonOf(ctx)), nil
 		case "!=":
-			return rLeft != rRight, nil
+			return !valuesEqual(rLeft, rRight, epsilonOf(ctx)), nil
 		}
 	}
-	return nil, errors.New(fmt.Sprintf("The operator '%s' is not supported", e.operator))
+	return nil, e.wrap("comparison", errors.New(fmt.Sprintf("The operator '%s' is not supported", e.operator)), rLeft, rRight)
+}
+
+// compareValues compares two strings lexicographically, by their code points,
+// or two numbers.
+func compareValues(left, right interface{}) (int, error) {
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+		return 0, NewWrongTypeError(left)
+	}
+	return compareNumbers(left, right)
+}
+
+type ExpressionLogical struct {
+	spanned
+	left     Expression
+	operator string
+	right    Expression
+}
+
+func NewExpressionLogical(left Expression, operator string, right Expression) *ExpressionLogical {
+	return &ExpressionLogical{
+		left:     left,
+		operator: operator,
+		right:    right,
+	}
+}
+
+func (e *ExpressionLogical) Left() Expression {
+	return e.left
+}
+
+func (e *ExpressionLogical) Operator() string {
+	return e.operator
+}
+
+func (e *ExpressionLogical) Right() Expression {
+	return e.right
+}
+
+// Solve evaluates the left operand first and only evaluates the right one
+// when it can still change the result ("||" and "&&" short-circuit). Both
+// operands are converted to booleans using the same rules of the `if`
+// function.
+func (e *ExpressionLogical) Solve(ctx Context) (interface{}, error) {
+	ctx, err := checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch e.operator {
+	case "||", "&&", "xor":
+	default:
+		return nil, e.wrap("logical", errors.New(fmt.Sprintf("The operator '%s' is not supported", e.operator)))
+	}
+	rLeft, err := e.left.Solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	left := IsTruthy(rLeft)
+	if e.operator == "||" && left {
+		return true, nil
+	}
+	if e.operator == "&&" && !left {
+		return false, nil
+	}
+	rRight, err := e.right.Solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	right := IsTruthy(rRight)
+	if e.operator == "xor" {
+		return left != right, nil
+	}
+	return right, nil
+}
+
+type ExpressionNot struct {
+	spanned
+	expression Expression
+}
+
+func NewExpressionNot(expression Expression) *ExpressionNot {
+	return &ExpressionNot{
+		expression: expression,
+	}
+}
+
+func (e *ExpressionNot) Expression() Expression {
+	return e.expression
+}
+
+func (e *ExpressionNot) Solve(ctx Context) (interface{}, error) {
+	ctx, err := checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v, err := e.expression.Solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return !IsTruthy(v), nil
+}
+
+// ExpressionConditional is the `condition ? then : else` operator. Only the
+// branch chosen by the condition is solved, using the truthiness rules of the
+// `if` function.
+type ExpressionConditional struct {
+	spanned
+	condition Expression
+	then      Expression
+	otherwise Expression
+}
+
+func NewExpressionConditional(condition Expression, then Expression, otherwise Expression) *ExpressionConditional {
+	return &ExpressionConditional{
+		condition: condition,
+		then:      then,
+		otherwise: otherwise,
+	}
+}
+
+func (e *ExpressionConditional) Condition() Expression {
+	return e.condition
+}
+
+func (e *ExpressionConditional) Then() Expression {
+	return e.then
+}
+
+func (e *ExpressionConditional) Else() Expression {
+	return e.otherwise
+}
+
+func (e *ExpressionConditional) Solve(ctx Context) (interface{}, error) {
+	ctx, err := checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	condition, err := e.condition.Solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if IsTruthy(condition) {
+		return e.then.Solve(ctx)
+	}
+	return e.otherwise.Solve(ctx)
+}
+
+// IsTruthy reports whether a solved value is considered true: booleans are
+// taken as they are, numbers are true when different from zero, strings when
+// not empty and anything else when not nil.
+func IsTruthy(v interface{}) bool {
+	if n, ok := toFloat64(v); ok {
+		return n != 0
+	}
+	switch vv := v.(type) {
+	case bool:
+		return vv
+	case string:
+		return vv != ""
+	case complex128:
+		return vv != 0
+	case complex64:
+		return vv != 0
+	default:
+		return vv != nil
+	}
 }
 
 type ExpressionBrackets struct {
+	spanned
 	inner Expression
 }
 
@@ -260,11 +566,16 @@ func NewExpressionBrackets(expression Expression) *ExpressionBrackets {
 	}
 }
 
+func (e *ExpressionBrackets) Inner() Expression {
+	return e.inner
+}
+
 func (e *ExpressionBrackets) Solve(ctx Context) (interface{}, error) {
 	return e.inner.Solve(ctx)
 }
 
 type ExpressionFunction struct {
+	spanned
 	name   string
 	params []Expression
 }
@@ -276,6 +587,36 @@ func NewExpressionFunction(name string, params ... Expression) *ExpressionFuncti
 	}
 }
 
+func (e *ExpressionFunction) Name() string {
+	return e.name
+}
+
+// Params returns a copy of the parameters of the call.
+func (e *ExpressionFunction) Params() []Expression {
+	return append([]Expression(nil), e.params...)
+}
+
 func (e *ExpressionFunction) Solve(ctx Context) (interface{}, error) {
-	return ctx.Functions().Call(ctx, e.name, e.params...)
+	ctx, err := checkpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return e.call(ctx, e.params)
+}
+
+func (e *ExpressionFunction) call(ctx Context, params []Expression) (interface{}, error) {
+	if s, ok := ctx.(*solveContext); ok {
+		if s.limits.MaxCallDepth > 0 && s.callDepth >= s.limits.MaxCallDepth {
+			return nil, &LimitExceededError{Limit: LimitCallDepth, Max: s.limits.MaxCallDepth}
+		}
+		s.callDepth++
+		defer func() {
+			s.callDepth--
+		}()
+	}
+	r, err := ctx.Functions().Call(ctx, e.name, params...)
+	if err != nil {
+		return nil, e.wrap("function", err)
+	}
+	return r, checkLength(ctx, r)
 }
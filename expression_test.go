@@ -13,6 +13,18 @@ import (
 type ExpressionFail struct {
 }
 
+// customContext implements only the methods required by Context.
+type customContext struct {
+}
+
+func (*customContext) Resolver() expressions.Resolver {
+	return nil
+}
+
+func (*customContext) Functions() expressions.Functions {
+	return nil
+}
+
 func (*ExpressionFail) Solve(ctx expressions.Context) (interface{}, error) {
 	return nil, errors.New("failed")
 }
@@ -69,7 +81,7 @@ func TestExpressions(t *testing.T) {
 				Expect(v).NotTo(BeNil())
 				r, err := v.Solve(ctx)
 				Expect(err).To(BeNil())
-				Expect(r).To(Equal(float64(3)))
+				Expect(r).To(Equal(int64(3)))
 			})
 
 			g.It("should solve an addition of floats", func() {
@@ -131,7 +143,7 @@ func TestExpressions(t *testing.T) {
 				v.Add("", expressions.NewExpressionValue(2))
 				v.Add("-", expressions.NewExpressionValue(1))
 				Expect(v).NotTo(BeNil())
-				Expect(v.Solve(ctx)).To(Equal(float64(1)))
+				Expect(v.Solve(ctx)).To(Equal(int64(1)))
 			})
 
 			g.It("should solve an multiplication", func() {
@@ -140,7 +152,7 @@ func TestExpressions(t *testing.T) {
 				v.Add("", expressions.NewExpressionValue(2))
 				v.Add("*", expressions.NewExpressionValue(4))
 				Expect(v).NotTo(BeNil())
-				Expect(v.Solve(ctx)).To(Equal(float64(8)))
+				Expect(v.Solve(ctx)).To(Equal(int64(8)))
 			})
 
 			g.It("should solve an division", func() {
@@ -149,7 +161,7 @@ func TestExpressions(t *testing.T) {
 				v.Add("", expressions.NewExpressionValue(16))
 				v.Add("/", expressions.NewExpressionValue(2))
 				Expect(v).NotTo(BeNil())
-				Expect(v.Solve(ctx)).To(Equal(float64(8)))
+				Expect(v.Solve(ctx)).To(Equal(int64(8)))
 			})
 
 			g.It("should solve an exponentiation", func() {
@@ -158,10 +170,25 @@ func TestExpressions(t *testing.T) {
 				v.Add("", expressions.NewExpressionValue(4))
 				v.Add("^", expressions.NewExpressionValue(2))
 				Expect(v).NotTo(BeNil())
-				Expect(v.Solve(ctx)).To(Equal(float64(16)))
+				Expect(v.Solve(ctx)).To(Equal(int64(16)))
 			})
 		})
 
+		g.It("should concatenate strings", func() {
+			expr := expressions.NewExpressionMultiple()
+			expr.Add("", expressions.NewExpressionValue("John"))
+			expr.Add("+", expressions.NewExpressionValue(" "))
+			expr.Add("+", expressions.NewExpressionValue("Doe"))
+			v, err := expr.Solve(expressions.NewContext(nil, nil))
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal("John Doe"))
+
+			expr = expressions.NewExpressionMultiple()
+			expr.Add("", expressions.NewExpressionValue("John"))
+			_, err = expr.Solve(expressions.NewContext(nil, nil))
+			Expect(err).NotTo(BeNil())
+		})
+
 		g.Describe("ExpressionMultiplePart", func() {
 			g.It("should create an expression", func() {
 				expr := expressions.NewExpressionMultiplePart("", nil)
@@ -171,7 +198,7 @@ func TestExpressions(t *testing.T) {
 			g.It("should solve an expression with empty operator", func() {
 				expr := expressions.NewExpressionMultiplePart("", expressions.NewExpressionValue(1))
 				Expect(expr).NotTo(BeNil())
-				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				v, err := expr.Apply(expressions.NewContext(nil, nil), 0)
 				Expect(err).To(BeNil())
 				Expect(v).To(Equal(1))
 			})
@@ -179,14 +206,14 @@ func TestExpressions(t *testing.T) {
 			g.It("should fail due to expression solving failure injection", func() {
 				expr := expressions.NewExpressionMultiplePart("", &ExpressionFail{})
 				Expect(expr).NotTo(BeNil())
-				_, err := expr.Solve(expressions.NewContext(nil, nil))
+				_, err := expr.Apply(expressions.NewContext(nil, nil), 0)
 				Expect(err).NotTo(BeNil())
 			})
 
 			g.It("should fail due to invalid operator", func() {
 				expr := expressions.NewExpressionMultiplePart("invalid operator", expressions.NewExpressionValue(4))
 				Expect(expr).NotTo(BeNil())
-				_, err := expr.Solve(expressions.NewContext(nil, nil))
+				_, err := expr.Apply(expressions.NewContext(nil, nil), 0)
 				Expect(err).NotTo(BeNil())
 				Expect(fmt.Sprint(err)).To(ContainSubstring("The operator"))
 				Expect(fmt.Sprint(err)).To(ContainSubstring("invalid operator"))
@@ -198,8 +225,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("+", expressions.NewExpressionValue(1))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 3.5)
 					Expect(err).To(BeNil())
 					Expect(v).To(Equal(float64(4.5)))
 				})
@@ -208,8 +234,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("+", expressions.NewExpressionValue(float64(1.5)))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 3.5)
 					Expect(err).To(BeNil())
 					Expect(v).To(Equal(float64(5)))
 				})
@@ -218,8 +243,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("+", &ExpressionFail{})
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(Equal("failed"))
 				})
@@ -228,8 +252,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("+", expressions.NewExpressionValue("wrong data type"))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(ContainSubstring("not a valid type"))
 				})
@@ -240,8 +263,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("-", expressions.NewExpressionValue(1))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 3.5)
 					Expect(err).To(BeNil())
 					Expect(v).To(Equal(float64(2.5)))
 				})
@@ -250,8 +272,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("-", expressions.NewExpressionValue(float64(1.5)))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 3.5)
 					Expect(err).To(BeNil())
 					Expect(v).To(Equal(float64(2)))
 				})
@@ -260,8 +281,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("-", &ExpressionFail{})
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(Equal("failed"))
 				})
@@ -270,8 +290,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("-", expressions.NewExpressionValue("wrong data type"))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(ContainSubstring("not a valid type"))
 				})
@@ -282,8 +301,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("*", expressions.NewExpressionValue(2))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 3.5)
 					Expect(err).To(BeNil())
 					Expect(v).To(Equal(float64(7)))
 				})
@@ -292,8 +310,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("*", expressions.NewExpressionValue(float64(1.5)))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 3)
 					Expect(err).To(BeNil())
 					Expect(v).To(Equal(float64(4.5)))
 				})
@@ -302,8 +319,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("*", &ExpressionFail{})
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(Equal("failed"))
 				})
@@ -312,8 +328,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("*", expressions.NewExpressionValue("wrong data type"))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(ContainSubstring("not a valid type"))
 				})
@@ -324,8 +339,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("/", expressions.NewExpressionValue(2))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.6)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 3.6)
 					Expect(err).To(BeNil())
 					Expect(v).To(Equal(float64(1.8)))
 				})
@@ -334,8 +348,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("/", expressions.NewExpressionValue(float64(1.8)))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.6)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 3.6)
 					Expect(err).To(BeNil())
 					Expect(v).To(Equal(float64(2)))
 				})
@@ -344,8 +357,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("/", &ExpressionFail{})
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(Equal("failed"))
 				})
@@ -354,8 +366,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("/", expressions.NewExpressionValue("wrong data type"))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(ContainSubstring("not a valid type"))
 				})
@@ -366,28 +377,25 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("%", expressions.NewExpressionValue(2))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(5)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 5)
 					Expect(err).To(BeNil())
-					Expect(v).To(Equal(1))
+					Expect(v).To(Equal(int64(1)))
 				})
 
 				g.It("should solve an expression with float params", func() {
 					expr := expressions.NewExpressionMultiplePart("%", expressions.NewExpressionValue(float64(3)))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(5)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 5)
 					Expect(err).To(BeNil())
-					Expect(v).To(Equal(2))
+					Expect(v).To(Equal(float64(2)))
 				})
 
 				g.It("should fail with injected expression solving failure", func() {
 					expr := expressions.NewExpressionMultiplePart("%+", &ExpressionFail{})
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(Equal("failed"))
 				})
@@ -396,8 +404,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("%", expressions.NewExpressionValue("wrong data type"))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(ContainSubstring("not a valid type"))
 				})
@@ -408,18 +415,16 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("^", expressions.NewExpressionValue(2))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(4)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 4)
 					Expect(err).To(BeNil())
-					Expect(v).To(Equal(float64(16)))
+					Expect(v).To(Equal(int64(16)))
 				})
 
 				g.It("should solve an expression with float params", func() {
 					expr := expressions.NewExpressionMultiplePart("^", expressions.NewExpressionValue(float64(1.8)))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.6)
-					v, err := expr.Solve(ctx)
+					v, err := expr.Apply(ctx, 3.6)
 					Expect(err).To(BeNil())
 					Expect(v).To(BeNumerically("~", 10.031006259, 0.0000001))
 				})
@@ -428,8 +433,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("^", &ExpressionFail{})
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(Equal("failed"))
 				})
@@ -438,8 +442,7 @@ func TestExpressions(t *testing.T) {
 					expr := expressions.NewExpressionMultiplePart("^", expressions.NewExpressionValue("wrong data type"))
 					Expect(expr).NotTo(BeNil())
 					ctx := expressions.NewContext(nil, nil)
-					ctx.SetAccumulated(3.5)
-					_, err := expr.Solve(ctx)
+					_, err := expr.Apply(ctx, 3.5)
 					Expect(err).NotTo(BeNil())
 					Expect(fmt.Sprint(err)).To(ContainSubstring("not a valid type"))
 				})
@@ -741,6 +744,23 @@ func TestExpressions(t *testing.T) {
 				})
 			})
 
+			g.It("should solve false for every relational operator with NaN", func() {
+				ctx := expressions.NewContext(nil, nil)
+				for _, operator := range []string{"<", ">", "<=", ">="} {
+					for _, operands := range [][]interface{}{{math.NaN(), 0.0}, {0.0, math.NaN()}, {math.NaN(), int64(0)}, {math.NaN(), math.NaN()}} {
+						expr := expressions.NewExpressionBinary(expressions.NewExpressionValue(operands[0]), operator, expressions.NewExpressionValue(operands[1]))
+						r, err := expr.Solve(ctx)
+						Expect(err).To(BeNil())
+						Expect(r).To(BeFalse(), fmt.Sprint(operands[0], operator, operands[1]))
+					}
+				}
+				expr, err := expressions.Compile("sqrt(-1) >= 0 || sqrt(-1) <= 0")
+				Expect(err).To(BeNil())
+				r, err := expr.Solve(expressions.NewContext(nil, &expressions.DefaultFunctions{}))
+				Expect(err).To(BeNil())
+				Expect(r).To(BeFalse())
+			})
+
 			g.Describe("== (equal)", func() {
 				g.It("should solve true with integer parameters", func() {
 					expr := expressions.NewExpressionBinary(expressions.NewExpressionValue(3), "==", expressions.NewExpressionValue(3))
@@ -796,6 +816,104 @@ func TestExpressions(t *testing.T) {
 					Expect(r).To(BeFalse())
 				})
 
+				g.It("should compare numbers of different kinds by value", func() {
+					expr := expressions.NewExpressionBinary(expressions.NewExpressionValue(int(1)), "==", expressions.NewExpressionValue(float64(1)))
+					r, err := expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeTrue())
+					expr = expressions.NewExpressionBinary(expressions.NewExpressionValue(uint8(200)), "==", expressions.NewExpressionValue(int64(200)))
+					r, err = expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeTrue())
+				})
+
+				g.It("should not round large integers compared to floats", func() {
+					expr := expressions.NewExpressionBinary(expressions.NewExpressionValue(int64(9007199254740993)), "==", expressions.NewExpressionValue(float64(9007199254740992)))
+					r, err := expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeFalse())
+				})
+
+				g.It("should not consider numbers equal to strings or booleans", func() {
+					expr := expressions.NewExpressionBinary(expressions.NewExpressionValue(1), "==", expressions.NewExpressionValue("1"))
+					r, err := expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeFalse())
+					expr = expressions.NewExpressionBinary(expressions.NewExpressionValue(1), "==", expressions.NewExpressionValue(true))
+					r, err = expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeFalse())
+				})
+
+				g.It("should solve with nil parameters", func() {
+					var m map[string]interface{}
+					expr := expressions.NewExpressionBinary(expressions.NewExpressionValue(m), "==", expressions.NewExpressionValue(nil))
+					r, err := expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeTrue())
+					expr = expressions.NewExpressionBinary(expressions.NewExpressionValue(0), "==", expressions.NewExpressionValue(nil))
+					r, err = expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeFalse())
+				})
+
+				g.It("should compare slices structurally", func() {
+					expr := expressions.NewExpressionBinary(expressions.NewExpressionValue([]int{1, 2}), "==", expressions.NewExpressionValue([]interface{}{1.0, int64(2)}))
+					r, err := expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeTrue())
+					expr = expressions.NewExpressionBinary(expressions.NewExpressionValue([]int{1, 2}), "==", expressions.NewExpressionValue([]int{1, 2, 3}))
+					r, err = expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeFalse())
+				})
+
+				g.It("should compare maps structurally", func() {
+					expr := expressions.NewExpressionBinary(expressions.NewExpressionValue(map[string]int{"a": 1}), "==", expressions.NewExpressionValue(map[string]interface{}{"a": 1.0}))
+					r, err := expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeTrue())
+					expr = expressions.NewExpressionBinary(expressions.NewExpressionValue(map[interface{}]string{1: "a"}), "==", expressions.NewExpressionValue(map[interface{}]string{int64(1): "a"}))
+					r, err = expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeTrue())
+					expr = expressions.NewExpressionBinary(expressions.NewExpressionValue(map[string]int{"a": 1}), "==", expressions.NewExpressionValue(map[string]int{"b": 1}))
+					r, err = expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeFalse())
+				})
+
+				g.It("should compare floats exactly by default", func() {
+					a, b := 0.1, 0.2
+					expr := expressions.NewExpressionBinary(expressions.NewExpressionValue(a+b), "==", expressions.NewExpressionValue(0.3))
+					r, err := expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeFalse())
+				})
+
+				g.It("should compare floats with the context epsilon", func() {
+					a, b := 0.1, 0.2
+					expr := expressions.NewExpressionBinary(expressions.NewExpressionValue(a+b), "==", expressions.NewExpressionValue(0.3))
+					r, err := expr.Solve(expressions.NewContext(nil, nil).SetEpsilon(1e-9))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeTrue())
+					expr = expressions.NewExpressionBinary(expressions.NewExpressionValue(0.3), "!=", expressions.NewExpressionValue(0.31))
+					r, err = expr.Solve(expressions.NewContext(nil, nil).SetEpsilon(1e-9))
+					Expect(err).To(BeNil())
+					Expect(r).To(BeTrue())
+				})
+
+				g.It("should compare floats exactly with contexts without epsilon", func() {
+					a, b := 0.1, 0.2
+					expr := expressions.NewExpressionBinary(expressions.NewExpressionValue(a+b), "==", expressions.NewExpressionValue(0.3))
+					r, err := expr.Solve(&customContext{})
+					Expect(err).To(BeNil())
+					Expect(r).To(BeFalse())
+					r, err = expressions.Solve(expressions.NewExpressionBinary(expressions.NewExpressionValue(0.3), "==", expressions.NewExpressionValue(0.3)), &customContext{})
+					Expect(err).To(BeNil())
+					Expect(r).To(BeTrue())
+				})
+
 				g.It("should fail with injected expression solving error (1st param)", func() {
 					expr := expressions.NewExpressionBinary(&ExpressionFail{}, "==", expressions.NewExpressionValue(1.5))
 					ctx := expressions.NewContext(nil, nil)
@@ -839,6 +957,120 @@ func TestExpressions(t *testing.T) {
 			})
 		})
 
+		g.Describe("ExpressionLogical", func() {
+			g.It("should create an expression", func() {
+				expr := expressions.NewExpressionLogical(expressions.NewExpressionValue(true), "&&", expressions.NewExpressionValue(true))
+				Expect(expr).NotTo(BeNil())
+			})
+
+			g.It("should fail due to invalid operator", func() {
+				expr := expressions.NewExpressionLogical(expressions.NewExpressionValue(true), "invalid operator", expressions.NewExpressionValue(true))
+				_, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(ContainSubstring("invalid operator"))
+				Expect(fmt.Sprint(err)).To(ContainSubstring("is not supported"))
+			})
+
+			g.It("should solve ||", func() {
+				expr := expressions.NewExpressionLogical(expressions.NewExpressionValue(false), "||", expressions.NewExpressionValue(true))
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+				expr = expressions.NewExpressionLogical(expressions.NewExpressionValue(false), "||", expressions.NewExpressionValue(false))
+				v, err = expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(false))
+			})
+
+			g.It("should solve &&", func() {
+				expr := expressions.NewExpressionLogical(expressions.NewExpressionValue(true), "&&", expressions.NewExpressionValue(true))
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+				expr = expressions.NewExpressionLogical(expressions.NewExpressionValue(true), "&&", expressions.NewExpressionValue(false))
+				v, err = expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(false))
+			})
+
+			g.It("should solve xor", func() {
+				expr := expressions.NewExpressionLogical(expressions.NewExpressionValue(true), "xor", expressions.NewExpressionValue(false))
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+				expr = expressions.NewExpressionLogical(expressions.NewExpressionValue(true), "xor", expressions.NewExpressionValue(true))
+				v, err = expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(false))
+			})
+
+			g.It("should convert numbers and strings to booleans", func() {
+				expr := expressions.NewExpressionLogical(expressions.NewExpressionValue(1), "&&", expressions.NewExpressionValue("John Doe"))
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+				expr = expressions.NewExpressionLogical(expressions.NewExpressionValue(0.0), "||", expressions.NewExpressionValue(""))
+				v, err = expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(false))
+			})
+
+			g.It("should not solve the right side when || is short-circuited", func() {
+				expr := expressions.NewExpressionLogical(expressions.NewExpressionValue(true), "||", &ExpressionFail{})
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should not solve the right side when && is short-circuited", func() {
+				expr := expressions.NewExpressionLogical(expressions.NewExpressionValue(false), "&&", &ExpressionFail{})
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(false))
+			})
+
+			g.It("should fail with injected expression solving failure", func() {
+				expr := expressions.NewExpressionLogical(&ExpressionFail{}, "xor", expressions.NewExpressionValue(true))
+				_, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(Equal("failed"))
+				expr = expressions.NewExpressionLogical(expressions.NewExpressionValue(true), "xor", &ExpressionFail{})
+				_, err = expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(Equal("failed"))
+			})
+		})
+
+		g.Describe("ExpressionNot", func() {
+			g.It("should create an expression", func() {
+				expr := expressions.NewExpressionNot(expressions.NewExpressionValue(true))
+				Expect(expr).NotTo(BeNil())
+			})
+
+			g.It("should negate a boolean", func() {
+				expr := expressions.NewExpressionNot(expressions.NewExpressionValue(true))
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(false))
+			})
+
+			g.It("should negate numbers, strings and nil", func() {
+				for value, expected := range map[interface{}]bool{0: true, 1.5: false, "": true, "John Doe": false, nil: true} {
+					expr := expressions.NewExpressionNot(expressions.NewExpressionValue(value))
+					v, err := expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(v).To(Equal(expected))
+				}
+			})
+
+			g.It("should fail with injected expression solving failure", func() {
+				expr := expressions.NewExpressionNot(&ExpressionFail{})
+				_, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(Equal("failed"))
+			})
+		})
+
 		g.Describe("ExpressionBrackets", func() {
 			g.It("should create an expression", func() {
 				expr := expressions.NewExpressionBrackets(expressions.NewExpressionValue(0.1))
@@ -869,5 +1101,40 @@ func TestExpressions(t *testing.T) {
 				Expect(v).To(Equal("John Doe"))
 			})
 		})
+
+		g.Describe("ExpressionConditional", func() {
+			g.It("should create an expression", func() {
+				expr := expressions.NewExpressionConditional(expressions.NewExpressionValue(true), expressions.NewExpressionValue(1), expressions.NewExpressionValue(2))
+				Expect(expr).NotTo(BeNil())
+			})
+
+			g.It("should solve only the chosen branch", func() {
+				expr := expressions.NewExpressionConditional(expressions.NewExpressionValue(true), expressions.NewExpressionValue(1), &ExpressionFail{})
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(1))
+
+				expr = expressions.NewExpressionConditional(expressions.NewExpressionValue(false), &ExpressionFail{}, expressions.NewExpressionValue(2))
+				v, err = expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(2))
+			})
+
+			g.It("should choose the branch by the truthiness of numbers, strings and nil", func() {
+				for value, expected := range map[interface{}]string{0: "else", 1.5: "then", "": "else", "John Doe": "then", nil: "else"} {
+					expr := expressions.NewExpressionConditional(expressions.NewExpressionValue(value), expressions.NewExpressionValue("then"), expressions.NewExpressionValue("else"))
+					v, err := expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil())
+					Expect(v).To(Equal(expected))
+				}
+			})
+
+			g.It("should fail with injected expression solving failure", func() {
+				expr := expressions.NewExpressionConditional(&ExpressionFail{}, expressions.NewExpressionValue(1), expressions.NewExpressionValue(2))
+				_, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(Equal("failed"))
+			})
+		})
 	})
 }
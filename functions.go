@@ -2,8 +2,8 @@ package expressions
 
 import (
 	"math"
-	"errors"
 	"fmt"
+	"strings"
 )
 
 type Functions interface {
@@ -13,349 +13,93 @@ type Functions interface {
 type ParameterError struct {
 	name       string
 	paramCount uint
+	optional   uint
+	variadic   bool
 }
 
 func (err *ParameterError) Error() string {
+	if err.variadic {
+		return fmt.Sprintf("'%s' expects at least %d parameters.", err.name, err.paramCount)
+	}
+	if err.optional > 0 {
+		return fmt.Sprintf("'%s' expects %d to %d parameters.", err.name, err.paramCount, err.paramCount+err.optional)
+	}
 	return fmt.Sprintf("'%s' expects %d parameters.", err.name, err.paramCount)
 }
 
 type DefaultFunctions struct {
 }
 
+var defaultFunctions = NewDefaultFunctionRegistry()
+
 func (*DefaultFunctions) Call(ctx Context, name string, params ... Expression) (interface{}, error) {
-	switch name {
-	case "cos":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Cos(float64(rr)), nil
-		case float64:
-			return math.Cos(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "cosh":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Cosh(float64(rr)), nil
-		case float64:
-			return math.Cosh(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "acos":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Acos(float64(rr)), nil
-		case float64:
-			return math.Acos(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "acosh":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Acosh(float64(rr)), nil
-		case float64:
-			return math.Acosh(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "sin":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Sin(float64(rr)), nil
-		case float64:
-			return math.Sin(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "sinh":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Sinh(float64(rr)), nil
-		case float64:
-			return math.Sinh(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "asin":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Asin(float64(rr)), nil
-		case float64:
-			return math.Asin(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "asinh":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Asinh(float64(rr)), nil
-		case float64:
-			return math.Asinh(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "sqrt":
-		if len(params) != 1 && len(params) != 2 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		var (
-			param1 float64
-			param2 float64
-		)
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			param1 = float64(rr)
-		case float64:
-			param1 = rr
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-		if len(params) > 1 {
-			r, err = params[1].Solve(ctx)
-			if err != nil {
-				return nil, err
-			}
-			switch rr := r.(type) {
-			case int:
-				param2 = float64(rr)
-			case float64:
-				param2 = rr
-			default:
-				return nil, NewWrongTypeError(r)
-			}
-			return math.Pow(param1, float64(1)/param2), nil
-		}
-		return math.Sqrt(param1), nil
-	case "tan":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Tan(float64(rr)), nil
-		case float64:
-			return math.Tan(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "atan":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Atan(float64(rr)), nil
-		case float64:
-			return math.Atan(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "atan2":
-		if len(params) != 2 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 2,
-			}
-		}
-		var (
-			param1 float64
-			param2 float64
-		)
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			param1 = float64(rr)
-		case float64:
-			param1 = rr
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-		r, err = params[1].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			param2 = float64(rr)
-		case float64:
-			param2 = rr
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-		return math.Atan2(param1, param2), nil
-	case "atanh":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Atanh(float64(rr)), nil
-		case float64:
-			return math.Atanh(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "log":
-		if len(params) != 1 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 1,
-			}
-		}
-		r, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		switch rr := r.(type) {
-		case int:
-			return math.Log(float64(rr)), nil
-		case float64:
-			return math.Log(rr), nil
-		default:
-			return nil, NewWrongTypeError(r)
-		}
-	case "if":
-		if len(params) != 3 {
-			return nil, &ParameterError{
-				name:       name,
-				paramCount: 3,
-			}
-		}
-		condition, err := params[0].Solve(ctx)
-		if err != nil {
-			return nil, err
-		}
-		c := false
-		switch cc := condition.(type) {
-		case bool:
-			c = cc
-		case int:
-			c = cc != 0
-		case float64:
-			c = cc != 0
-		case string:
-			c = cc != ""
-		default:
-			c = cc != nil
-		}
-		if c {
-			return params[1].Solve(ctx)
+	return defaultFunctions.Call(ctx, name, params...)
+}
+
+// NewDefaultFunctionRegistry creates a registry with the functions provided by
+// DefaultFunctions, which can then be extended.
+func NewDefaultFunctionRegistry() *FunctionRegistry {
+	registry := NewFunctionRegistry()
+	registry.MustRegister("cos", math.Cos)
+	registry.MustRegister("cosh", math.Cosh)
+	registry.MustRegister("acos", math.Acos)
+	registry.MustRegister("acosh", math.Acosh)
+	registry.MustRegister("sin", math.Sin)
+	registry.MustRegister("sinh", math.Sinh)
+	registry.MustRegister("asin", math.Asin)
+	registry.MustRegister("asinh", math.Asinh)
+	registry.MustRegister("sqrt", sqrt)
+	registry.MustRegister("tan", math.Tan)
+	registry.MustRegister("atan", math.Atan)
+	registry.MustRegister("atan2", math.Atan2)
+	registry.MustRegister("atanh", math.Atanh)
+	registry.MustRegister("log", math.Log)
+	registry.RegisterLazy("if", ifFunction)
+	registry.MustRegister("len", stringLength)
+	registry.MustRegister("upper", strings.ToUpper)
+	registry.MustRegister("lower", strings.ToLower)
+	registry.MustRegister("trim", strings.TrimSpace)
+	registry.MustRegister("substr", substr)
+	registry.MustRegister("contains", strings.Contains)
+	registry.MustRegister("startsWith", strings.HasPrefix)
+	registry.MustRegister("endsWith", strings.HasSuffix)
+	registry.MustRegister("replace", replace)
+	registry.MustRegister("split", split)
+	registry.MustRegister("join", join)
+	registry.MustRegister("padLeft", padLeft)
+	registry.MustRegister("repeat", repeat)
+	registry.MustRegister("format", formatText)
+	return registry
+}
+
+// sqrt calculates the square root of x or, when given, its n-th root.
+func sqrt(x float64, n ...float64) (float64, error) {
+	switch len(n) {
+	case 0:
+		return math.Sqrt(x), nil
+	case 1:
+		return math.Pow(x, float64(1)/n[0]), nil
+	}
+	return 0, &ParameterError{
+		name:       "sqrt",
+		paramCount: 1,
+		optional:   1,
+	}
+}
+
+func ifFunction(ctx Context, params ... Expression) (interface{}, error) {
+	if len(params) != 3 {
+		return nil, &ParameterError{
+			name:       "if",
+			paramCount: 3,
 		}
-		return params[2].Solve(ctx)
-	default:
-		return nil, errors.New(fmt.Sprintf("The function '%s' is not defined.", name))
 	}
+	condition, err := params[0].Solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if IsTruthy(condition) {
+		return params[1].Solve(ctx)
+	}
+	return params[2].Solve(ctx)
 }
@@ -1,6 +1,7 @@
 package expressions_test
 
 import (
+	"errors"
 	"testing"
 	"github.com/jamillosantos/go-expressions"
 	"math"
@@ -436,7 +437,7 @@ func TestFunctions(t *testing.T) {
 				functions := &expressions.DefaultFunctions{}
 				_, err := functions.Call(expressions.NewContext(nil, nil), "sqrt", expressions.NewExpressionValue(0.1), expressions.NewExpressionValue(0.1), expressions.NewExpressionValue(0.1))
 				Expect(err).NotTo(BeNil())
-				Expect(fmt.Sprint(err)).To(ContainSubstring("sqrt"))
+				Expect(fmt.Sprint(err)).To(Equal("'sqrt' expects 1 to 2 parameters."))
 			})
 		})
 
@@ -787,5 +788,213 @@ func TestFunctions(t *testing.T) {
 				Expect(fmt.Sprint(err)).To(ContainSubstring("if"))
 			})
 		})
+
+		g.Describe("String functions", func() {
+			solve := func(source string, variables map[string]interface{}) (interface{}, error) {
+				expr, err := expressions.Compile(source)
+				Expect(err).To(BeNil())
+				return expressions.Solve(expr, expressions.NewContext(expressions.NewMapResolver(variables), &expressions.DefaultFunctions{}))
+			}
+
+			g.It("should count 'len' in characters", func() {
+				Expect(solve(`len("héllo") + len("") + len(name)`, map[string]interface{}{"name": "日本"})).To(Equal(int64(7)))
+			})
+
+			g.It("should change the case with 'upper' and 'lower'", func() {
+				Expect(solve(`upper("héllo, ö") + lower("ÀÉ")`, nil)).To(Equal("HÉLLO, Öàé"))
+			})
+
+			g.It("should remove the surrounding spaces with 'trim'", func() {
+				Expect(solve("trim(\" \\t a b\\n \")", nil)).To(Equal("a b"))
+			})
+
+			g.It("should take characters with 'substr'", func() {
+				Expect(solve(`substr("héllo", 1)`, nil)).To(Equal("éllo"))
+				Expect(solve(`substr("héllo", 1, 2)`, nil)).To(Equal("él"))
+				Expect(solve(`substr("héllo", -3, 2)`, nil)).To(Equal("ll"))
+				Expect(solve(`substr("héllo", 4, 10)`, nil)).To(Equal("o"))
+				Expect(solve(`substr("héllo", 10) + substr("héllo", -10, 1) + substr("héllo", 1, -1)`, nil)).To(Equal("h"))
+			})
+
+			g.It("should fail 'substr' with positions that are not integers or too many params", func() {
+				_, err := solve(`substr("héllo", 1.5)`, nil)
+				Expect(err).NotTo(BeNil())
+				_, err = solve(`substr("héllo", 1, 2, 3)`, nil)
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(ContainSubstring("'substr' expects 2 to 3 parameters."))
+			})
+
+			g.It("should search with 'contains', 'startsWith' and 'endsWith'", func() {
+				Expect(solve(`contains("héllo", "él") && startsWith("héllo", "hé") && endsWith("héllo", "lo")`, nil)).To(BeTrue())
+				Expect(solve(`contains("héllo", "x") || startsWith("héllo", "é") || endsWith("héllo", "l")`, nil)).To(BeFalse())
+			})
+
+			g.It("should replace all the occurrences with 'replace'", func() {
+				Expect(solve(`replace("a-b-c", "-", "→")`, nil)).To(Equal("a→b→c"))
+				Expect(solve(`replace("hé", "", "-")`, nil)).To(Equal("-h-é-"))
+			})
+
+			g.It("should limit the length of the result of 'replace' before building it", func() {
+				expr, err := expressions.Compile(`replace(s, "a", "bbbb")`)
+				Expect(err).To(BeNil())
+				ctx := expressions.NewContext(expressions.NewMapResolver(map[string]interface{}{"s": "a-a"}), &expressions.DefaultFunctions{})
+				Expect(expressions.Solve(expr, ctx.SetLimits(expressions.Limits{MaxLength: 9}))).To(Equal("bbbb-bbbb"))
+				_, err = expressions.Solve(expr, ctx.SetLimits(expressions.Limits{MaxLength: 8}))
+				var limitErr *expressions.LimitExceededError
+				Expect(errors.As(err, &limitErr)).To(BeTrue())
+				Expect(limitErr).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitLength, Max: 8}))
+			})
+
+			g.It("should split and join lists", func() {
+				Expect(solve(`split("a,é,,c", ",")`, nil)).To(Equal([]interface{}{"a", "é", "", "c"}))
+				Expect(solve(`join(split("a,é,c", ","), " | ")`, nil)).To(Equal("a | é | c"))
+				Expect(solve(`join(items, "-")`, map[string]interface{}{
+					"items": []interface{}{1, 2.5, nil, "x", true},
+				})).To(Equal("1-2.5--x-true"))
+				_, err := solve(`join("abc", ",")`, nil)
+				Expect(err).NotTo(BeNil())
+			})
+
+			g.It("should pad with 'padLeft'", func() {
+				Expect(solve(`padLeft("7", 3)`, nil)).To(Equal("  7"))
+				Expect(solve(`padLeft("7", 3, "0")`, nil)).To(Equal("007"))
+				Expect(solve(`padLeft("é", 6, "ab")`, nil)).To(Equal("ababaé"))
+				Expect(solve(`padLeft("héllo", 3, "0")`, nil)).To(Equal("héllo"))
+				_, err := solve(`padLeft("7", 3, "")`, nil)
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(ContainSubstring("cannot be empty"))
+				_, err = solve(`padLeft("7", 3, "0", "1")`, nil)
+				Expect(fmt.Sprint(err)).To(ContainSubstring("'padLeft' expects 2 to 3 parameters."))
+			})
+
+			g.It("should repeat strings with 'repeat'", func() {
+				Expect(solve(`repeat("é-", 3)`, nil)).To(Equal("é-é-é-"))
+				Expect(solve(`repeat("ab", 0)`, nil)).To(Equal(""))
+				_, err := solve(`repeat("ab", -1)`, nil)
+				Expect(err).NotTo(BeNil())
+				_, err = solve(`repeat("ab", 2000000000)`, nil)
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(ContainSubstring("too long"))
+			})
+
+			g.It("should replace the placeholders with 'format'", func() {
+				Expect(solve(`format("{0} has {1} items, {0}!", "é", 3)`, nil)).To(Equal("é has 3 items, é!"))
+				Expect(solve(`format("{{0}} {x} {} {1}", 1.5, 0.1 + 0.2)`, nil)).To(Equal("{0} {x} {} 0.30000000000000004"))
+				Expect(solve(`format("none")`, nil)).To(Equal("none"))
+				_, err := solve(`format("{0} {1}", 1)`, nil)
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(ContainSubstring("{1}"))
+			})
+		})
+	})
+
+	g.Describe("FunctionRegistry", func() {
+		g.It("should fail registering values that are not functions", func() {
+			registry := expressions.NewFunctionRegistry()
+			Expect(registry.Register("pi", 3.14)).NotTo(BeNil())
+			Expect(registry.Register("none", func() {})).NotTo(BeNil())
+			Expect(registry.Register("many", func() (int, int) { return 1, 2 })).NotTo(BeNil())
+		})
+
+		g.It("should call a registered function converting the parameters", func() {
+			registry := expressions.NewFunctionRegistry().MustRegister("max", math.Max)
+			v, err := registry.Call(expressions.NewContext(nil, nil), "max", expressions.NewExpressionValue(int8(3)), expressions.NewExpressionValue(float32(2.5)))
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(float64(3)))
+		})
+
+		g.It("should convert integral numbers to integer parameters", func() {
+			registry := expressions.NewFunctionRegistry().MustRegister("half", func(v int) int { return v / 2 })
+			v, err := registry.Call(expressions.NewContext(nil, nil), "half", expressions.NewExpressionValue(float64(8)))
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(4))
+			_, err = registry.Call(expressions.NewContext(nil, nil), "half", expressions.NewExpressionValue(8.5))
+			Expect(err).NotTo(BeNil())
+			Expect(fmt.Sprint(err)).To(ContainSubstring("not a valid type"))
+		})
+
+		g.It("should fail when a number does not fit the parameter", func() {
+			registry := expressions.NewFunctionRegistry().MustRegister("byte", func(v uint8) uint8 { return v })
+			_, err := registry.Call(expressions.NewContext(nil, nil), "byte", expressions.NewExpressionValue(256))
+			Expect(err).NotTo(BeNil())
+			_, err = registry.Call(expressions.NewContext(nil, nil), "byte", expressions.NewExpressionValue(-1))
+			Expect(err).NotTo(BeNil())
+		})
+
+		g.It("should check the parameter types", func() {
+			registry := expressions.NewFunctionRegistry().MustRegister("greet", func(name string) (string, error) { return "Hello " + name, nil })
+			v, err := registry.Call(expressions.NewContext(nil, nil), "greet", expressions.NewExpressionValue("John"))
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal("Hello John"))
+			_, err = registry.Call(expressions.NewContext(nil, nil), "greet", expressions.NewExpressionValue(1))
+			Expect(err).NotTo(BeNil())
+			Expect(fmt.Sprint(err)).To(ContainSubstring("not a valid type"))
+		})
+
+		g.It("should check the number of parameters", func() {
+			registry := expressions.NewFunctionRegistry().MustRegister("max", math.Max)
+			_, err := registry.Call(expressions.NewContext(nil, nil), "max", expressions.NewExpressionValue(1))
+			Expect(err).NotTo(BeNil())
+			Expect(fmt.Sprint(err)).To(Equal("'max' expects 2 parameters."))
+		})
+
+		g.It("should call variadic functions", func() {
+			registry := expressions.NewFunctionRegistry().MustRegister("sum", func(first float64, others ...float64) float64 {
+				for _, o := range others {
+					first += o
+				}
+				return first
+			})
+			v, err := registry.Call(expressions.NewContext(nil, nil), "sum", expressions.NewExpressionValue(1), expressions.NewExpressionValue(2), expressions.NewExpressionValue(3.5))
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(6.5))
+			_, err = registry.Call(expressions.NewContext(nil, nil), "sum")
+			Expect(err).NotTo(BeNil())
+			Expect(fmt.Sprint(err)).To(Equal("'sum' expects at least 1 parameters."))
+		})
+
+		g.It("should return the error of the function", func() {
+			registry := expressions.NewFunctionRegistry().MustRegister("fail", func() (int, error) { return 0, errors.New("failed") })
+			_, err := registry.Call(expressions.NewContext(nil, nil), "fail")
+			Expect(err).NotTo(BeNil())
+			Expect(fmt.Sprint(err)).To(Equal("failed"))
+		})
+
+		g.It("should return an error when the function panics", func() {
+			registry := expressions.NewFunctionRegistry().MustRegister("first", func(values []interface{}) interface{} { return values[0] })
+			_, err := registry.Call(expressions.NewContext(nil, nil), "first", expressions.NewExpressionValue([]interface{}{}))
+			Expect(err).NotTo(BeNil())
+			Expect(fmt.Sprint(err)).To(ContainSubstring("The function 'first' panicked"))
+		})
+
+		g.It("should pass the context to functions that expect it", func() {
+			registry := expressions.NewFunctionRegistry().MustRegister("lookup", func(ctx expressions.Context, name string) (interface{}, error) {
+				return ctx.Resolver().Resolve(name)
+			})
+			resolver := expressions.NewMapResolver(map[string]interface{}{
+				"x": 1,
+			})
+			v, err := registry.Call(expressions.NewContext(resolver, nil), "lookup", expressions.NewExpressionValue("x"))
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(1))
+		})
+
+		g.It("should extend the default functions", func() {
+			registry := expressions.NewDefaultFunctionRegistry().MustRegister("double", func(v float64) float64 { return v * 2 })
+			expr, err := expressions.Compile("double(cos(0)) + if(true, 1, 2)")
+			Expect(err).To(BeNil())
+			v, err := expr.Solve(expressions.NewContext(nil, registry))
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(float64(3)))
+		})
+
+		g.It("should fail calling unknown functions", func() {
+			registry := expressions.NewFunctionRegistry()
+			_, err := registry.Call(expressions.NewContext(nil, nil), "unknown")
+			Expect(err).NotTo(BeNil())
+			Expect(fmt.Sprint(err)).To(ContainSubstring("is not defined"))
+			registry.MustRegister("unknown", math.Abs).Unregister("unknown")
+			Expect(registry.Has("unknown")).To(BeFalse())
+		})
 	})
 }
@@ -0,0 +1,304 @@
+package expressions
+
+import (
+	"strings"
+)
+
+type tokenKind int
+
+// The kinds of tokens have the same order of the token types generated by
+// ANTLR for Expression.g4, so both parsers report the expected tokens in the
+// same order.
+const (
+	tokenEOF tokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenPlus
+	tokenMinus
+	tokenTimes
+	tokenDiv
+	tokenMod
+	tokenGT
+	tokenLT
+	tokenGTE
+	tokenLTE
+	tokenEQ
+	tokenNotEQ
+	tokenNot
+	tokenOr
+	tokenAnd
+	tokenXor
+	tokenComma
+	tokenPoint
+	tokenPow
+	tokenQuestion
+	tokenColon
+	tokenPI
+	tokenEuler
+	tokenI
+	tokenTrue
+	tokenFalse
+	tokenNull
+	tokenVariable
+	tokenQuotedString
+	tokenQuote
+	tokenScientificNumber
+)
+
+var tokenNames = []string{
+	"<EOF>", "'('", "')'", "'['", "']'", "'+'", "'-'", "'*'", "'/'", "'%'", "'>'",
+	"'<'", "'>='", "'<='", "'=='", "'!='", "'!'", "'||'", "'&&'", "'xor'",
+	"','", "'.'", "'^'", "'?'", "':'", "'pi'", "EULER", "'i'", "'true'", "'false'", "'null'",
+	"VARIABLE", "QUOTED_STRING", "'\"'", "SCIENTIFIC_NUMBER",
+}
+
+func (kind tokenKind) String() string {
+	return tokenNames[kind]
+}
+
+var keywords = map[string]tokenKind{
+	"xor":   tokenXor,
+	"pi":    tokenPI,
+	"e":     tokenEuler,
+	"i":     tokenI,
+	"true":  tokenTrue,
+	"false": tokenFalse,
+	"null":  tokenNull,
+}
+
+var operators = map[rune]tokenKind{
+	'(': tokenLParen,
+	')': tokenRParen,
+	'[': tokenLBracket,
+	']': tokenRBracket,
+	'+': tokenPlus,
+	'-': tokenMinus,
+	'*': tokenTimes,
+	'/': tokenDiv,
+	'%': tokenMod,
+	',': tokenComma,
+	'.': tokenPoint,
+	'^': tokenPow,
+	'?': tokenQuestion,
+	':': tokenColon,
+}
+
+// token is a lexeme of the source. Start and End are character offsets, End
+// being exclusive.
+type token struct {
+	kind   tokenKind
+	text   string
+	start  int
+	end    int
+	line   int
+	column int
+}
+
+// display renders a token the way ANTLR does in its error messages.
+func (t *token) display() string {
+	if t.kind == tokenEOF {
+		return "'<EOF>'"
+	}
+	return "'" + escapeWhitespace(t.text) + "'"
+}
+
+func escapeWhitespace(s string) string {
+	return strings.NewReplacer("\n", "\\n", "\r", "\\r", "\t", "\\t").Replace(s)
+}
+
+// lexer splits the source in tokens following the lexer rules of
+// Expression.g4: the longest lexeme is taken and characters that do not start
+// any token are reported and skipped.
+type lexer struct {
+	source []rune
+	pos    int
+	line   int
+	column int
+	tokens []token
+	errors []*SyntaxError
+}
+
+func newLexer(source []rune) *lexer {
+	return &lexer{
+		source: source,
+		line:   1,
+	}
+}
+
+const eof rune = -1
+
+func (l *lexer) peek(offset int) rune {
+	if l.pos+offset < len(l.source) {
+		return l.source[l.pos+offset]
+	}
+	return eof
+}
+
+// advance moves over n characters keeping track of lines and columns.
+func (l *lexer) advance(n int) {
+	for ; n > 0 && l.pos < len(l.source); n-- {
+		if l.source[l.pos] == '\n' {
+			l.line++
+			l.column = 0
+		} else {
+			l.column++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) emit(kind tokenKind, length int) {
+	l.tokens = append(l.tokens, token{
+		kind:   kind,
+		text:   string(l.source[l.pos : l.pos+length]),
+		start:  l.pos,
+		end:    l.pos + length,
+		line:   l.line,
+		column: l.column,
+	})
+	l.advance(length)
+}
+
+// fail reports the characters that could not be matched, including the one
+// that made the match fail, and skips all of them, as ANTLR does.
+func (l *lexer) fail(length int) {
+	if l.pos+length > len(l.source) {
+		length = len(l.source) - l.pos
+	}
+	l.errors = append(l.errors, &SyntaxError{
+		Line:    l.line,
+		Column:  l.column,
+		Message: "token recognition error at: '" + string(l.source[l.pos:l.pos+length]) + "'",
+		Snippet: snippet(string(l.source), l.line, l.column, 1),
+	})
+	l.advance(length)
+}
+
+func (l *lexer) scan() ([]token, []*SyntaxError) {
+	for l.pos < len(l.source) {
+		c := l.source[l.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			l.advance(1)
+		case operators[c] != tokenEOF:
+			l.emit(operators[c], 1)
+		case c == '>':
+			l.emitPair('=', tokenGTE, tokenGT)
+		case c == '<':
+			l.emitPair('=', tokenLTE, tokenLT)
+		case c == '!':
+			l.emitPair('=', tokenNotEQ, tokenNot)
+		case c == '=':
+			l.emitPair('=', tokenEQ, tokenEOF)
+		case c == '|':
+			l.emitPair('|', tokenOr, tokenEOF)
+		case c == '&':
+			l.emitPair('&', tokenAnd, tokenEOF)
+		case isIdentifierStart(c):
+			n := 1
+			for isIdentifierStart(l.peek(n)) || isDigit(l.peek(n)) {
+				n++
+			}
+			kind, ok := keywords[string(l.source[l.pos:l.pos+n])]
+			if !ok {
+				kind = tokenVariable
+			}
+			l.emit(kind, n)
+		case isDigit(c):
+			l.emit(tokenScientificNumber, l.number())
+		case c == '"' || c == '\'':
+			n, ok := l.quotedString()
+			switch {
+			case ok:
+				l.emit(tokenQuotedString, n)
+			case c == '"':
+				l.emit(tokenQuote, 1)
+			default:
+				l.fail(n + 1)
+			}
+		default:
+			l.fail(1)
+		}
+	}
+	l.tokens = append(l.tokens, token{
+		kind:   tokenEOF,
+		text:   "<EOF>",
+		start:  l.pos,
+		end:    l.pos,
+		line:   l.line,
+		column: l.column,
+	})
+	return l.tokens, l.errors
+}
+
+// emitPair emits pair when the current character is followed by next and
+// single otherwise. Characters that are only valid in pairs have no single
+// kind (tokenEOF).
+func (l *lexer) emitPair(next rune, pair tokenKind, single tokenKind) {
+	switch {
+	case l.peek(1) == next:
+		l.emit(pair, 2)
+	case single != tokenEOF:
+		l.emit(single, 1)
+	default:
+		l.fail(2)
+	}
+}
+
+// number returns the length of the number at the current position. The
+// fraction and the exponent are only taken when they are complete, otherwise
+// they start other tokens (e.g. "1e" is a number followed by `e`).
+func (l *lexer) number() int {
+	digits := func(n int) int {
+		for isDigit(l.peek(n)) {
+			n++
+		}
+		if l.peek(n) == '.' && isDigit(l.peek(n+1)) {
+			n += 2
+			for isDigit(l.peek(n)) {
+				n++
+			}
+		}
+		return n
+	}
+	n := digits(0)
+	if e := l.peek(n); e == 'e' || e == 'E' {
+		exponent := n + 1
+		if s := l.peek(exponent); s == '+' || s == '-' {
+			exponent++
+		}
+		if isDigit(l.peek(exponent)) {
+			n = digits(exponent)
+		}
+	}
+	return n
+}
+
+// quotedString returns the length of the string started by the quote at the
+// current position. When the string is not closed in the same line, it returns
+// the offset of the character that made it fail and false.
+func (l *lexer) quotedString() (int, bool) {
+	quote := l.peek(0)
+	for n := 1; ; n++ {
+		switch l.peek(n) {
+		case eof, '\n', '\r':
+			return n, false
+		case quote:
+			return n + 1, true
+		case '\\':
+			if c := l.peek(n + 1); c != eof && c != '\n' && c != '\r' {
+				n++
+			}
+		}
+	}
+}
+
+func isIdentifierStart(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
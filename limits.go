@@ -0,0 +1,108 @@
+package expressions
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// DefaultMaxDepth is how deeply the expressions compiled by a new Compiler can
+// be nested.
+const DefaultMaxDepth = 1000
+
+// DefaultMaxChain is how long the chains of operators of the expressions
+// compiled by a new Compiler can be.
+const DefaultMaxChain = 10000
+
+// The limits a LimitExceededError can report.
+const (
+	LimitDepth     = "depth"
+	LimitChain     = "chain"
+	LimitSteps     = "steps"
+	LimitLength    = "length"
+	LimitCallDepth = "call depth"
+)
+
+// Limits bound the work of solving an expression with a context that has them,
+// like a BaseContext given to SetLimits. Zero values are not limited.
+type Limits struct {
+	// MaxSteps is how many operators, accesses, fields and calls can be
+	// evaluated.
+	MaxSteps int
+	// MaxLength is the length of the strings, in bytes, and of the slices,
+	// arrays and maps that fields, accesses and calls can give.
+	MaxLength int
+	// MaxCallDepth is how deeply function calls can be nested, including the
+	// calls made by functions that solve other expressions.
+	MaxCallDepth int
+}
+
+type LimitExceededError struct {
+	Limit string
+	Max   int
+}
+
+func (err *LimitExceededError) Error() string {
+	return fmt.Sprintf("The %s limit of %d was exceeded.", err.Limit, err.Max)
+}
+
+// exceedsDepth returns the limit e exceeds, if any, when it can be nested
+// depth more levels and its chains can have chain more links. Like the parser,
+// it only counts as nested the operands of unary operators, parameters of
+// calls, indexes, branches of conditionals and parentheses. The operands of
+// chains of operators, members and indexes are links, which add up along the
+// chains nested in one another. Negative values are not limited.
+func exceedsDepth(e Expression, depth int, chain int) string {
+	switch {
+	case depth == 0:
+		return LimitDepth
+	case chain == 0:
+		return LimitChain
+	}
+	for i, c := range children(e) {
+		n := nesting(e, i)
+		if limit := exceedsDepth(c, depth-n, chain-1+n); limit != "" {
+			return limit
+		}
+	}
+	return ""
+}
+
+// nesting returns 1 when the i-th child of e, as children returns them, is
+// nested in it and 0 otherwise.
+func nesting(e Expression, i int) int {
+	switch ee := e.(type) {
+	case *ExpressionNot, *ExpressionFunction, *ExpressionBrackets:
+		return 1
+	case *ExpressionMultiple:
+		if _, _, ok := unary(ee); ok {
+			return 1
+		}
+	case *ExpressionIndex:
+		if i == 1 {
+			return 1
+		}
+	case *ExpressionConditional:
+		if i > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+func lengthOf(v interface{}) int {
+	switch vv := v.(type) {
+	case nil, bool, int64, float64:
+		return 0
+	case string:
+		return len(vv)
+	case []interface{}:
+		return len(vv)
+	case map[string]interface{}:
+		return len(vv)
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len()
+	}
+	return 0
+}
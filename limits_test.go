@@ -0,0 +1,232 @@
+package expressions_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"github.com/jamillosantos/go-expressions"
+	. "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestLimits(t *testing.T) {
+	g := Goblin(t)
+
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Compile limits", func() {
+		g.It("should reject expressions nested too deeply", func() {
+			for _, s := range []string{
+				strings.Repeat("(", 5000) + "1" + strings.Repeat(")", 5000),
+				strings.Repeat("-", 5000) + "1",
+				strings.Repeat("f(", 5000) + "1" + strings.Repeat(")", 5000),
+				strings.Repeat("(", 5000) + "1",
+				strings.Repeat("a ? a : ", 5000) + "a",
+				strings.Repeat("a[", 5000) + "0" + strings.Repeat("]", 5000),
+			} {
+				_, err := expressions.Compile(s)
+				Expect(err).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitDepth, Max: expressions.DefaultMaxDepth}))
+			}
+		})
+
+		g.It("should reject expressions nested too deeply with the ANTLR parser", func() {
+			for _, s := range []string{
+				strings.Repeat("(", 1100) + "1" + strings.Repeat(")", 1100),
+				strings.Repeat("a ? a : ", 1100) + "a",
+			} {
+				_, err := expressions.NewCompiler().CompileANTLR(s)
+				Expect(err).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitDepth, Max: expressions.DefaultMaxDepth}))
+			}
+		})
+
+		g.It("should accept long chains of operators", func() {
+			terms := make([]string, 1200)
+			for i := range terms {
+				terms[i] = fmt.Sprintf("id == %d", i+1)
+			}
+			for _, s := range []string{"a" + strings.Repeat(" && a", 2000), "a" + strings.Repeat(" + a", 2000), "a" + strings.Repeat(".a", 2000)} {
+				_, err := expressions.Compile(s)
+				Expect(err).To(BeNil())
+			}
+			expr, err := expressions.Compile(strings.Join(terms, " || "))
+			Expect(err).To(BeNil())
+			v, err := expr.Solve(expressions.NewContext(expressions.NewMapResolver(map[string]interface{}{
+				"id": int64(1200),
+			}), nil))
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(true))
+		})
+
+		g.It("should reject chains of operators too long", func() {
+			for _, s := range []string{
+				"a" + strings.Repeat(" && a", expressions.DefaultMaxChain),
+				"a" + strings.Repeat(" == a", expressions.DefaultMaxChain),
+				"a" + strings.Repeat(".a", expressions.DefaultMaxChain),
+				"a" + strings.Repeat("[0]", expressions.DefaultMaxChain),
+			} {
+				_, err := expressions.Compile(s)
+				Expect(err).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitChain, Max: expressions.DefaultMaxChain}))
+			}
+			compiler := expressions.NewCompiler().SetMaxChain(3)
+			_, err := compiler.Compile("a && b || c.d")
+			Expect(err).To(BeNil())
+			_, err = compiler.Compile("a && b && c || d")
+			Expect(err).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitChain, Max: 3}))
+			_, err = compiler.Compile("a.b.c.d")
+			Expect(err).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitChain, Max: 3}))
+			_, err = compiler.Compile("a + (b + (c + d))")
+			Expect(err).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitChain, Max: 3}))
+			_, err = expressions.NewCompiler().SetMaxChain(0).Compile("a" + strings.Repeat(" && a", expressions.DefaultMaxChain))
+			Expect(err).To(BeNil())
+		})
+
+				g.It("should accept expressions within the configured depth", func() {
+			compiler := expressions.NewCompiler().SetMaxDepth(3)
+			_, err := compiler.Compile("((a))")
+			Expect(err).To(BeNil())
+			_, err = compiler.Compile("(((a)))")
+			Expect(err).To(BeAssignableToTypeOf(&expressions.LimitExceededError{}))
+			_, err = compiler.Compile("a && b && c && d || -f(a) + b[1].c")
+			Expect(err).To(BeNil())
+			_, err = compiler.Compile("-f(a[1])")
+			Expect(err).To(BeAssignableToTypeOf(&expressions.LimitExceededError{}))
+			_, err = compiler.Compile("a ? b : c ? d : e")
+			Expect(err).To(BeNil())
+			_, err = compiler.Compile("a ? b : c ? d : e ? f : g")
+			Expect(err).To(BeAssignableToTypeOf(&expressions.LimitExceededError{}))
+
+			_, err = expressions.NewCompiler().SetMaxDepth(0).Compile(strings.Repeat("(", 5000) + "1" + strings.Repeat(")", 5000))
+			Expect(err).To(BeNil())
+		})
+	})
+
+	g.Describe("Solve limits", func() {
+		functions := expressions.NewDefaultFunctionRegistry().
+			RegisterLazy("repeat", func(ctx expressions.Context, params ...expressions.Expression) (interface{}, error) {
+				for {
+					if _, err := params[0].Solve(ctx); err != nil {
+						return nil, err
+					}
+				}
+			}).
+			RegisterLazy("nest", func(ctx expressions.Context, params ...expressions.Expression) (interface{}, error) {
+				return params[0].Solve(ctx)
+			}).
+			MustRegister("list", func(n int) []interface{} {
+				return make([]interface{}, n)
+			})
+		resolver := expressions.NewMapResolver(map[string]interface{}{
+			"a":     int64(1),
+			"b":     int64(2),
+			"name":  "john",
+			"items": []interface{}{"x", "a longer string"},
+		})
+		solvers := map[string]func(expressions.Expression) expressions.Expression{
+			"tree": func(e expressions.Expression) expressions.Expression {
+				return e
+			},
+			"program": func(e expressions.Expression) expressions.Expression {
+				return expressions.NewProgram(e)
+			},
+		}
+		solve := func(s string, limits expressions.Limits) map[string]error {
+			expr, err := expressions.Compile(s)
+			Expect(err).To(BeNil(), s)
+			errs := make(map[string]error)
+			for name, solver := range solvers {
+				_, errs[name] = expressions.Solve(solver(expr), expressions.NewContext(resolver, functions).SetLimits(limits))
+			}
+			return errs
+		}
+
+		g.It("should limit the steps", func() {
+			for name, err := range solve("a + b * (a - b) > 1 || !b", expressions.Limits{MaxSteps: 11}) {
+				Expect(err).To(BeNil(), name)
+			}
+			for name, err := range solve("a + b * (a - b) > 1 || !b", expressions.Limits{MaxSteps: 10}) {
+				Expect(err).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitSteps, Max: 10}), name)
+			}
+			for name, err := range solve("repeat(a + 1)", expressions.Limits{MaxSteps: 1000}) {
+				var limitErr *expressions.LimitExceededError
+				Expect(errors.As(err, &limitErr)).To(BeTrue(), name)
+				Expect(limitErr.Limit).To(Equal(expressions.LimitSteps))
+			}
+		})
+
+		g.It("should exceed the steps at the same node in trees and programs", func() {
+			for _, s := range []string{
+				"len(name) > 1 && upper(name) == \"JOHN\"",
+				"a > b ? nest(a + 1) : -nest(b * 2)",
+				"if(a < b, items[1], items[0]) + name",
+				"!(a == 1) || a xor b || nest(items[a])",
+				"len(upper(name + items[0])) * (a - b)",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				for steps := 1; ; steps++ {
+					limits := expressions.Limits{MaxSteps: steps}
+					tree, treeErr := expressions.Solve(expr, expressions.NewContext(resolver, functions).SetLimits(limits))
+					program, programErr := expressions.Solve(expressions.NewProgram(expr), expressions.NewContext(resolver, functions).SetLimits(limits))
+					Expect([]interface{}{program, programErr}).To(Equal([]interface{}{tree, treeErr}), s)
+					if treeErr == nil {
+						break
+					}
+				}
+			}
+		})
+
+		g.It("should limit the length of strings and collections", func() {
+			for name, err := range solve("items[0] == \"x\" && name != \"\" && items[0] + \"yz\" != name", expressions.Limits{MaxLength: 4}) {
+				Expect(err).To(BeNil(), name)
+			}
+			for _, s := range []string{"items[1]", "list(5)", "list(2) == list(5)", "name + \"!\"", "\"\" + items[0] + name"} {
+				for name, err := range solve(s, expressions.Limits{MaxLength: 4}) {
+					var limitErr *expressions.LimitExceededError
+					Expect(errors.As(err, &limitErr)).To(BeTrue(), name+": "+s)
+					Expect(limitErr).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitLength, Max: 4}))
+				}
+			}
+		})
+
+		g.It("should limit the length of strings built by functions before building them", func() {
+			for _, s := range []string{"repeat(\"ab\", 3)", "padLeft(\"a\", 5, \"0\")", "padLeft(\"a\", 1000000000)", "upper(name + name)"} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				_, err = expressions.Solve(expr, expressions.NewContext(resolver, &expressions.DefaultFunctions{}).SetLimits(expressions.Limits{MaxLength: 4}))
+				Expect(err).To(MatchError(ContainSubstring("length")), s)
+			}
+		})
+
+		g.It("should limit the nesting of calls", func() {
+			for name, err := range solve("nest(nest(nest(a)))", expressions.Limits{MaxCallDepth: 3}) {
+				Expect(err).To(BeNil(), name)
+			}
+			for name, err := range solve("nest(nest(nest(a)))", expressions.Limits{MaxCallDepth: 2}) {
+				var limitErr *expressions.LimitExceededError
+				Expect(errors.As(err, &limitErr)).To(BeTrue(), name)
+				Expect(limitErr).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitCallDepth, Max: 2}))
+			}
+		})
+
+		g.It("should limit expressions solved directly", func() {
+			for name, solver := range solvers {
+				expr, err := expressions.Compile("a + b")
+				Expect(err).To(BeNil())
+				_, err = solver(expr).Solve(expressions.NewContext(resolver, functions).SetLimits(expressions.Limits{MaxSteps: 1}))
+				Expect(err).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitSteps, Max: 1}), name)
+
+				expr, err = expressions.Compile("repeat(\"ab\", 1000)")
+				Expect(err).To(BeNil())
+				_, err = solver(expr).Solve(expressions.NewContext(resolver, &expressions.DefaultFunctions{}).SetLimits(expressions.Limits{MaxLength: 10}))
+				var limitErr *expressions.LimitExceededError
+				Expect(errors.As(err, &limitErr)).To(BeTrue(), name)
+				Expect(limitErr).To(Equal(&expressions.LimitExceededError{Limit: expressions.LimitLength, Max: 10}))
+
+				v, err := solver(expr).Solve(expressions.NewContext(resolver, &expressions.DefaultFunctions{}))
+				Expect(err).To(BeNil(), name)
+				Expect(v).To(HaveLen(2000))
+			}
+		})
+	})
+}
@@ -0,0 +1,171 @@
+package expressions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+type MemberError struct {
+	path    string
+	segment string
+	reason  string
+}
+
+func NewMemberError(path string, segment string, reason string) *MemberError {
+	return &MemberError{
+		path:    path,
+		segment: segment,
+		reason:  reason,
+	}
+}
+
+func (err *MemberError) Error() string {
+	return fmt.Sprintf("Cannot access %s of %s: %s.", err.segment, err.path, err.reason)
+}
+
+func (err *MemberError) Path() string {
+	return err.path
+}
+
+func (err *MemberError) Segment() string {
+	return err.segment
+}
+
+// lookupMember finds a member by name on maps with string keys and on the
+// fields of structs (see structMembers). Methods are not called, so formulas
+// cannot cause side effects through the values they reach.
+func lookupMember(v interface{}, name string) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, errors.New("the value is nil")
+		}
+		if rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.Struct {
+			break
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, errors.New(fmt.Sprintf("%s has no string keys", rv.Type()))
+		}
+		r := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+		if !r.IsValid() {
+			return nil, errors.New("the key was not found")
+		}
+		return r.Interface(), nil
+	case reflect.Struct, reflect.Ptr:
+		r, ok, err := lookupStructMember(rv, name, false)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("%s has no field %s", rv.Type(), name))
+		}
+		return r, nil
+	case reflect.Invalid:
+		return nil, errors.New("the value is nil")
+	}
+	return nil, errors.New(fmt.Sprintf("%s has no members", rv.Type()))
+}
+
+// lookupIndex indexes slices, arrays and strings by integer position and maps
+// by key. Strings used as index on structs are handled as member names.
+func lookupIndex(v interface{}, index interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, errors.New("the value is nil")
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		// Strings are indexed by characters instead of bytes.
+		rv = reflect.ValueOf([]rune(rv.String()))
+		r, err := lookupIndex(rv.Interface(), index)
+		if err != nil {
+			return nil, err
+		}
+		return string(r.(rune)), nil
+	case reflect.Slice, reflect.Array:
+		i, ok := integerIndex(index)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("%v is not a valid index", index))
+		}
+		if i < 0 || i >= int64(rv.Len()) {
+			return nil, errors.New(fmt.Sprintf("index %d is out of range [0, %d)", i, rv.Len()))
+		}
+		return rv.Index(int(i)).Interface(), nil
+	case reflect.Map:
+		key, ok := mapKey(rv.Type().Key(), index)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("%v is not a valid key for %s", index, rv.Type()))
+		}
+		r := rv.MapIndex(key)
+		if !r.IsValid() {
+			return nil, errors.New("the key was not found")
+		}
+		return r.Interface(), nil
+	case reflect.Struct:
+		if name, ok := index.(string); ok {
+			return lookupMember(rv.Interface(), name)
+		}
+	case reflect.Invalid:
+		return nil, errors.New("the value is nil")
+	}
+	return nil, errors.New(fmt.Sprintf("%s cannot be indexed", rv.Type()))
+}
+
+func integerIndex(index interface{}) (int64, bool) {
+	n, ok := normalizeNumber(index)
+	if !ok {
+		return 0, false
+	}
+	switch nn := n.(type) {
+	case int64:
+		return nn, true
+	case float64:
+		if nn != math.Trunc(nn) || math.Abs(nn) > math.MaxInt32 {
+			return 0, false
+		}
+		return int64(nn), true
+	}
+	return 0, false
+}
+
+func mapKey(t reflect.Type, index interface{}) (reflect.Value, bool) {
+	if index == nil {
+		return reflect.Value{}, false
+	}
+	kv := reflect.ValueOf(index)
+	if kv.Type().AssignableTo(t) {
+		return kv, true
+	}
+	if n, ok := normalizeNumber(index); ok {
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			i, ok := integerIndex(n)
+			if !ok || (i < 0 && t.Kind() >= reflect.Uint) {
+				return reflect.Value{}, false
+			}
+			k := reflect.ValueOf(i).Convert(t)
+			if k.Convert(reflect.TypeOf(i)).Int() != i {
+				return reflect.Value{}, false
+			}
+			return k, true
+		case reflect.Float32, reflect.Float64:
+			f, _ := toFloat64(n)
+			return reflect.ValueOf(f).Convert(t), true
+		}
+		return reflect.Value{}, false
+	}
+	if kv.Type().ConvertibleTo(t) && kv.Kind() == t.Kind() {
+		return kv.Convert(t), true
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,357 @@
+package expressions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+)
+
+var ErrDivisionByZero = errors.New("Division by zero.")
+
+type OverflowError struct {
+	left     interface{}
+	operator string
+	right    interface{}
+}
+
+func NewOverflowError(left interface{}, operator string, right interface{}) *OverflowError {
+	return &OverflowError{
+		left:     left,
+		operator: operator,
+		right:    right,
+	}
+}
+
+func (err *OverflowError) Error() string {
+	return fmt.Sprintf("%v %s %v overflows the integer range.", err.left, err.operator, err.right)
+}
+
+// normalizeNumber converts any Go numeric kind into one of the types used by
+// the arithmetic: int64, uint64 (only for values that do not fit an int64) or
+// float64.
+func normalizeNumber(v interface{}) (interface{}, bool) {
+	switch n := v.(type) {
+	case int64, float64:
+		// Returned as they are to avoid boxing them again.
+		return v, true
+	case int:
+		return int64(n), true
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case uint8:
+		return int64(n), true
+	case uint16:
+		return int64(n), true
+	case uint32:
+		return int64(n), true
+	case uint:
+		return normalizeUint(uint64(n)), true
+	case uint64:
+		return normalizeUint(n), true
+	case uintptr:
+		return normalizeUint(uint64(n)), true
+	case float32:
+		return float64(n), true
+	}
+	return nil, false
+}
+
+func normalizeUint(n uint64) interface{} {
+	if n <= math.MaxInt64 {
+		return int64(n)
+	}
+	return n
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	n, ok := normalizeNumber(v)
+	if !ok {
+		return 0, false
+	}
+	switch nn := n.(type) {
+	case int64:
+		return float64(nn), true
+	case uint64:
+		return float64(nn), true
+	default:
+		return nn.(float64), true
+	}
+}
+
+func isFloat(v interface{}) bool {
+	_, ok := v.(float64)
+	return ok
+}
+
+func isComplex(v interface{}) bool {
+	switch v.(type) {
+	case complex128, complex64:
+		return true
+	}
+	return false
+}
+
+// toComplex converts complex numbers and the other numbers to complex128.
+func toComplex(v interface{}) (complex128, bool) {
+	switch c := v.(type) {
+	case complex128:
+		return c, true
+	case complex64:
+		return complex128(c), true
+	}
+	f, ok := toFloat64(v)
+	return complex(f, 0), ok
+}
+
+// arithmetic applies a binary arithmetic operator to two numbers. Integer
+// operands produce integer results, unless the division is not exact or the
+// exponent is negative. Integer overflows are reported as OverflowError.
+func arithmetic(left interface{}, operator string, right interface{}) (interface{}, error) {
+	if isComplex(left) || isComplex(right) {
+		lc, ok := toComplex(left)
+		if !ok {
+			return nil, NewWrongTypeError(left)
+		}
+		rc, ok := toComplex(right)
+		if !ok {
+			return nil, NewWrongTypeError(right)
+		}
+		return complexArithmetic(lc, operator, rc)
+	}
+	l, ok := normalizeNumber(left)
+	if !ok {
+		return nil, NewWrongTypeError(left)
+	}
+	r, ok := normalizeNumber(right)
+	if !ok {
+		return nil, NewWrongTypeError(right)
+	}
+	if isFloat(l) || isFloat(r) {
+		lf, _ := toFloat64(l)
+		rf, _ := toFloat64(r)
+		return floatArithmetic(lf, operator, rf)
+	}
+	li, lok := l.(int64)
+	ri, rok := r.(int64)
+	if lok && rok {
+		return intArithmetic(li, operator, ri)
+	}
+	return bigArithmetic(l, operator, r)
+}
+
+func floatArithmetic(l float64, operator string, r float64) (interface{}, error) {
+	switch operator {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		return l / r, nil
+	case "%":
+		return math.Mod(l, r), nil
+	case "^":
+		return math.Pow(l, r), nil
+	}
+	return nil, errors.New(fmt.Sprintf("The operator '%s' is not supported.", operator))
+}
+
+// complexArithmetic is used when any of the operands is a complex number. The
+// modulo is not defined for them.
+func complexArithmetic(l complex128, operator string, r complex128) (interface{}, error) {
+	switch operator {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		return l / r, nil
+	case "^":
+		return complexPow(l, r), nil
+	}
+	return nil, errors.New(fmt.Sprintf("The operator '%s' is not supported with complex numbers.", operator))
+}
+
+// complexPow raises to small integer exponents by multiplying, which keeps
+// results like `i ^ 2` exact, and uses cmplx.Pow otherwise.
+func complexPow(l complex128, r complex128) complex128 {
+	n := real(r)
+	if imag(r) != 0 || n != math.Trunc(n) || math.Abs(n) > 64 {
+		return cmplx.Pow(l, r)
+	}
+	result, base := complex(1, 0), l
+	for e := int(math.Abs(n)); e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result *= base
+		}
+		base *= base
+	}
+	if n < 0 {
+		return 1 / result
+	}
+	return result
+}
+
+// intArithmetic is the fast path for int64 operands. Results leaving the int64
+// range are recomputed by bigArithmetic, which can still represent them as
+// uint64.
+func intArithmetic(l int64, operator string, r int64) (interface{}, error) {
+	switch operator {
+	case "+":
+		s := l + r
+		if (r > 0 && s < l) || (r < 0 && s > l) {
+			return bigArithmetic(l, operator, r)
+		}
+		return s, nil
+	case "-":
+		s := l - r
+		if (r > 0 && s > l) || (r < 0 && s < l) {
+			return bigArithmetic(l, operator, r)
+		}
+		return s, nil
+	case "*":
+		if l == 0 || r == 0 {
+			return int64(0), nil
+		}
+		s := l * r
+		if s/r != l || (l == -1 && r == math.MinInt64) || (r == -1 && l == math.MinInt64) {
+			return bigArithmetic(l, operator, r)
+		}
+		return s, nil
+	case "/":
+		if r == 0 {
+			return nil, ErrDivisionByZero
+		}
+		if l%r != 0 {
+			return float64(l) / float64(r), nil
+		}
+		if l == math.MinInt64 && r == -1 {
+			return bigArithmetic(l, operator, r)
+		}
+		return l / r, nil
+	case "%":
+		if r == 0 {
+			return nil, ErrDivisionByZero
+		}
+		return l % r, nil
+	case "^":
+		if r < 0 {
+			return math.Pow(float64(l), float64(r)), nil
+		}
+		return bigArithmetic(l, operator, r)
+	}
+	return nil, errors.New(fmt.Sprintf("The operator '%s' is not supported.", operator))
+}
+
+var (
+	bigMinInt64  = big.NewInt(math.MinInt64)
+	bigMaxUint64 = new(big.Int).SetUint64(math.MaxUint64)
+)
+
+func toBig(v interface{}) *big.Int {
+	if u, ok := v.(uint64); ok {
+		return new(big.Int).SetUint64(u)
+	}
+	return big.NewInt(v.(int64))
+}
+
+// bigArithmetic handles the integer operations that involve uint64 operands or
+// whose result may leave the int64 range but still fit an uint64.
+func bigArithmetic(left interface{}, operator string, right interface{}) (interface{}, error) {
+	l, r := toBig(left), toBig(right)
+	s := new(big.Int)
+	switch operator {
+	case "+":
+		s.Add(l, r)
+	case "-":
+		s.Sub(l, r)
+	case "*":
+		s.Mul(l, r)
+	case "/":
+		if r.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		m := new(big.Int)
+		s.QuoRem(l, r, m)
+		if m.Sign() != 0 {
+			lf, _ := toFloat64(left)
+			rf, _ := toFloat64(right)
+			return lf / rf, nil
+		}
+	case "%":
+		if r.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		s.Rem(l, r)
+	case "^":
+		if r.Sign() < 0 {
+			lf, _ := toFloat64(left)
+			rf, _ := toFloat64(right)
+			return math.Pow(lf, rf), nil
+		}
+		if r.BitLen() > 64 || (l.CmpAbs(big.NewInt(1)) > 0 && r.Cmp(big.NewInt(64)) > 0) {
+			return nil, NewOverflowError(left, operator, right)
+		}
+		s.Exp(l, r, nil)
+	default:
+		return nil, errors.New(fmt.Sprintf("The operator '%s' is not supported.", operator))
+	}
+	if s.Cmp(bigMinInt64) < 0 || s.Cmp(bigMaxUint64) > 0 {
+		return nil, NewOverflowError(left, operator, right)
+	}
+	if s.IsInt64() {
+		return s.Int64(), nil
+	}
+	return s.Uint64(), nil
+}
+
+// unordered is what compareNumbers returns when either operand is NaN, which
+// is neither lower than, equal to nor greater than any number.
+const unordered = 2
+
+// compareNumbers returns -1, 0 or 1 when left is lower than, equal to or
+// greater than right, and unordered when they cannot be ordered.
+func compareNumbers(left, right interface{}) (int, error) {
+	l, ok := normalizeNumber(left)
+	if !ok {
+		return 0, NewWrongTypeError(left)
+	}
+	r, ok := normalizeNumber(right)
+	if !ok {
+		return 0, NewWrongTypeError(right)
+	}
+	if isFloat(l) || isFloat(r) {
+		lf, _ := toFloat64(l)
+		rf, _ := toFloat64(r)
+		switch {
+		case math.IsNaN(lf) || math.IsNaN(rf):
+			return unordered, nil
+		case lf < rf:
+			return -1, nil
+		case lf > rf:
+			return 1, nil
+		}
+		return 0, nil
+	}
+	li, lok := l.(int64)
+	ri, rok := r.(int64)
+	if lok && rok {
+		switch {
+		case li < ri:
+			return -1, nil
+		case li > ri:
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return toBig(l).Cmp(toBig(r)), nil
+}
@@ -0,0 +1,249 @@
+package expressions
+
+// DefaultPureFunctions are the functions of DefaultFunctions whose results only
+// depend on their parameters, so they can be solved while optimizing. `repeat`,
+// `padLeft` and `replace` are left out, since their results can be much larger
+// than the expression and the optimizer does not enforce Limits.
+var DefaultPureFunctions = []string{
+	"cos", "cosh", "acos", "acosh", "sin", "sinh", "asin", "asinh", "sqrt",
+	"tan", "atan", "atan2", "atanh", "log", "if",
+	"len", "upper", "lower", "trim", "substr", "contains", "startsWith",
+	"endsWith", "split", "join", "format",
+}
+
+// Optimizer simplifies expressions before they are solved: sub-expressions
+// that only depend on constants are solved once, operations with no effect
+// (`x * 1 + 0`, `x - 0 + y`) are removed and brackets are collapsed. The optimized
+// expression solves to the same values and fails with the same errors, since
+// constant sub-expressions that fail are kept to fail when solved.
+type Optimizer struct {
+	functions Functions
+	pure      map[string]bool
+}
+
+// NewOptimizer creates an optimizer that solves the pure functions of
+// DefaultFunctions.
+func NewOptimizer() *Optimizer {
+	optimizer := &Optimizer{
+		functions: &DefaultFunctions{},
+		pure:      make(map[string]bool, len(DefaultPureFunctions)),
+	}
+	for _, name := range DefaultPureFunctions {
+		optimizer.pure[name] = true
+	}
+	return optimizer
+}
+
+// SetFunctions sets the functions the optimized expressions are going to be
+// solved with. Only the functions marked with SetPure are called.
+func (o *Optimizer) SetFunctions(functions Functions) *Optimizer {
+	o.functions = functions
+	return o
+}
+
+func (o *Optimizer) SetPure(name string, pure bool) *Optimizer {
+	if pure {
+		o.pure[name] = true
+	} else {
+		delete(o.pure, name)
+	}
+	return o
+}
+
+func Optimize(expression Expression) Expression {
+	return NewOptimizer().Optimize(expression)
+}
+
+// Optimize returns an optimized copy of expression, which is not changed.
+// Expressions of types unknown to the optimizer are kept as they are.
+func (o *Optimizer) Optimize(expression Expression) Expression {
+	switch e := expression.(type) {
+	case *ExpressionBrackets:
+		return o.Optimize(e.inner)
+	case *ExpressionMember:
+		r := NewExpressionMember(o.Optimize(e.expression), e.name)
+		copySpan(r, e)
+		return o.fold(r, r.expression)
+	case *ExpressionIndex:
+		r := NewExpressionIndex(o.Optimize(e.expression), o.Optimize(e.index))
+		copySpan(r, e)
+		return o.fold(r, r.expression, r.index)
+	case *ExpressionMultiple:
+		return o.optimizeMultiple(e)
+	case *ExpressionBinary:
+		r := NewExpressionBinary(o.Optimize(e.left), e.operator, o.Optimize(e.right))
+		copySpan(r, e)
+		if (r.operator == "==" || r.operator == "!=") && (!epsilonFree(r.left) || !epsilonFree(r.right)) {
+			return r
+		}
+		return o.fold(r, r.left, r.right)
+	case *ExpressionLogical:
+		r := NewExpressionLogical(o.Optimize(e.left), e.operator, o.Optimize(e.right))
+		copySpan(r, e)
+		if left, ok := r.left.(*ExpressionValue); ok && (r.operator == "||" || r.operator == "&&") {
+			// The right side is never solved when the left one decides.
+			if IsTruthy(left.value) == (r.operator == "||") {
+				return o.fold(r, r.left)
+			}
+		}
+		return o.fold(r, r.left, r.right)
+	case *ExpressionNot:
+		r := NewExpressionNot(o.Optimize(e.expression))
+		copySpan(r, e)
+		return o.fold(r, r.expression)
+	case *ExpressionConditional:
+		condition := o.Optimize(e.condition)
+		if c, ok := condition.(*ExpressionValue); ok {
+			if IsTruthy(c.value) {
+				return o.Optimize(e.then)
+			}
+			return o.Optimize(e.otherwise)
+		}
+		r := NewExpressionConditional(condition, o.Optimize(e.then), o.Optimize(e.otherwise))
+		copySpan(r, e)
+		return r
+	case *ExpressionFunction:
+		params := make([]Expression, len(e.params))
+		for i, p := range e.params {
+			params[i] = o.Optimize(p)
+		}
+		r := NewExpressionFunction(e.name, params...)
+		copySpan(r, e)
+		if !o.pure[r.name] {
+			return r
+		}
+		if r.name == "if" && len(params) == 3 {
+			if condition, ok := params[0].(*ExpressionValue); ok {
+				if IsTruthy(condition.value) {
+					return params[1]
+				}
+				return params[2]
+			}
+		}
+		return o.fold(r, params...)
+	}
+	return expression
+}
+
+func (o *Optimizer) optimizeMultiple(e *ExpressionMultiple) Expression {
+	r := NewExpressionMultiple()
+	copySpan(r, e)
+	var terms []*ExpressionMultiplePart
+	var identity *ExpressionMultiplePart
+	for i, term := range e.terms {
+		operand := o.Optimize(term.expression)
+		r.Add(term.operator, operand)
+		if i == 0 || !isIdentity(term.operator, operand) {
+			terms = append(terms, r.terms[i])
+		} else if identity == nil {
+			identity = r.terms[i]
+		}
+	}
+	// A single term still fails when it is not a number, which its source
+	// does not tell, so an identity is kept with it: `x * 1` stays as it is.
+	if len(terms) == 1 && identity != nil {
+		terms = append(terms, identity)
+	}
+	// Identities fail with strings, so they are kept when the terms could be
+	// strings concatenated, with or without them.
+	if !concatenates(r.terms) && !concatenates(terms) {
+		r.terms = terms
+	}
+	// Terms are combined from left to right, so the leading constants can be
+	// combined before the others are solved.
+	for len(r.terms) > 1 {
+		head := NewExpressionMultiple()
+		head.terms = r.terms[:2]
+		folded, ok := o.fold(head, head.terms[0].expression, head.terms[1].expression).(*ExpressionValue)
+		if !ok {
+			break
+		}
+		if _, ok := folded.value.(string); ok {
+			if !concatenates(r.terms) {
+				break
+			}
+			if len(r.terms) == 2 {
+				copySpan(folded, r)
+				return folded
+			}
+		}
+		r.terms = append([]*ExpressionMultiplePart{{expression: folded}}, r.terms[2:]...)
+	}
+	operands := make([]Expression, len(r.terms))
+	for i, term := range r.terms {
+		operands[i] = term.expression
+	}
+	return o.fold(r, operands...)
+}
+
+// fold solves e when all its operands are values. If solving fails, e is
+// kept so the error happens when it is solved. Values that have no literal,
+// like the slices of `split`, are not folded either, so the optimized
+// expression can still be printed as source.
+func (o *Optimizer) fold(e Expression, operands ...Expression) Expression {
+	for _, operand := range operands {
+		if _, ok := operand.(*ExpressionValue); !ok {
+			return e
+		}
+	}
+	v, err := e.Solve(NewContext(nil, o.functions))
+	if err != nil {
+		return e
+	}
+	if _, _, ok := formatValue(v); !ok {
+		return e
+	}
+	r := NewExpressionValue(v)
+	copySpan(r, e)
+	return r
+}
+
+// isIdentity reports whether operand is an integer constant that does not
+// change the result of the operator: `+ 0`, `- 0`, `* 1`, `/ 1` and `^ 1`.
+// Floats are not identities, since they turn integer results into floats. A
+// leading `0 +` or `1 *` is kept, as it changes the errors of non-numbers.
+func isIdentity(operator string, operand Expression) bool {
+	value, ok := operand.(*ExpressionValue)
+	if !ok {
+		return false
+	}
+	n, ok := normalizeNumber(value.value)
+	if !ok {
+		return false
+	}
+	i, ok := n.(int64)
+	if !ok {
+		return false
+	}
+	switch operator {
+	case "+", "-":
+		return i == 0
+	case "*", "/", "^":
+		return i == 1
+	}
+	return false
+}
+
+// epsilonFree reports whether the comparisons of an expression do not depend
+// on the epsilon of the context, which is unknown while optimizing.
+func epsilonFree(e Expression) bool {
+	value, ok := e.(*ExpressionValue)
+	if !ok {
+		return true
+	}
+	switch v := value.value.(type) {
+	case nil, string, bool:
+		return true
+	default:
+		n, ok := normalizeNumber(v)
+		return ok && !isFloat(n)
+	}
+}
+
+func copySpan(to Expression, from Expression) {
+	if f, ok := from.(Spanned); ok {
+		if t, ok := to.(Spanned); ok && f.Span() != nil {
+			t.SetSpan(f.Span())
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package expressions_test
+
+import (
+	"errors"
+	"testing"
+	"github.com/jamillosantos/go-expressions"
+	. "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestOptimize(t *testing.T) {
+	g := Goblin(t)
+
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Optimize", func() {
+		resolver := expressions.NewMapResolver(map[string]interface{}{
+			"a":    int64(3),
+			"b":    2.5,
+			"name": "john",
+			"flag": true,
+			"list": []interface{}{int64(1), "two", 3.0},
+		})
+		ctx := expressions.NewContext(resolver, &expressions.DefaultFunctions{})
+
+		g.It("should fold constant expressions", func() {
+			for s, expected := range map[string]interface{}{
+				"2 * 3 + cos(0)":        7.0,
+				"(1 + 2) * 4":           int64(12),
+				"1 < 2 && !false":       true,
+				"if(1 > 2, 10, 20)":     int64(20),
+				"1 > 2 ? 10 : 20":       int64(20),
+				"\"a\" + 'b' + \"c\"":  "abc",
+				"sqrt(16) ^ 2":          16.0,
+				"\"john\" == \"john\"": true,
+				"format(\"{0}-{1}\", upper(\"é\"), len(\"héllo\"))": "É-5",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				Expect(expressions.Optimize(expr)).To(BeAssignableToTypeOf(&expressions.ExpressionValue{}), s)
+				v, err := expressions.Optimize(expr).Solve(ctx)
+				Expect(err).To(BeNil(), s)
+				Expect(v).To(Equal(expected), s)
+			}
+		})
+
+		g.It("should fold the leading constants of a sum", func() {
+			product := expressions.NewExpressionMultiple()
+			product.Add("", expressions.NewExpressionValue(2))
+			product.Add("*", expressions.NewExpressionValue(3))
+			expr := expressions.NewExpressionMultiple()
+			expr.Add("", product)
+			expr.Add("+", expressions.NewExpressionValue(4))
+			expr.Add("-", expressions.NewExpressionField("a"))
+
+			expected := expressions.NewExpressionMultiple()
+			expected.Add("", expressions.NewExpressionValue(int64(10)))
+			expected.Add("-", expressions.NewExpressionField("a"))
+			Expect(expressions.Optimize(expr)).To(Equal(expected))
+		})
+
+		g.It("should remove identity operations", func() {
+			expr := expressions.NewExpressionMultiple()
+			expr.Add("", expressions.NewExpressionField("a"))
+			expr.Add("*", expressions.NewExpressionValue(1))
+			expr.Add("+", expressions.NewExpressionValue(0))
+
+			expected := expressions.NewExpressionMultiple()
+			expected.Add("", expressions.NewExpressionField("a"))
+			expected.Add("*", expressions.NewExpressionValue(1))
+			Expect(expressions.Optimize(expr)).To(Equal(expected))
+
+			expr.Add("-", expressions.NewExpressionField("b"))
+			expected = expressions.NewExpressionMultiple()
+			expected.Add("", expressions.NewExpressionField("a"))
+			expected.Add("-", expressions.NewExpressionField("b"))
+			Expect(expressions.Optimize(expr)).To(Equal(expected))
+
+			// Floats turn integers into floats, so they are kept.
+			expr = expressions.NewExpressionMultiple()
+			expr.Add("", expressions.NewExpressionField("a"))
+			expr.Add("*", expressions.NewExpressionValue(1.0))
+			Expect(expressions.Optimize(expr)).To(Equal(expr))
+		})
+
+		g.It("should collapse brackets", func() {
+			expr := expressions.NewExpressionBrackets(expressions.NewExpressionBrackets(expressions.NewExpressionField("a")))
+			Expect(expressions.Optimize(expr)).To(Equal(expressions.NewExpressionField("a")))
+		})
+
+		g.It("should not solve functions that are not pure", func() {
+			expr := expressions.NewExpressionFunction("cos", expressions.NewExpressionValue(0))
+			Expect(expressions.NewOptimizer().SetPure("cos", false).Optimize(expr)).To(Equal(expr))
+			for _, s := range []string{"repeat(\"ab\", 1000000)", "padLeft(\"a\", 1000000)", "replace(\"aaaa\", \"a\", \"bbbb\")"} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				Expect(expressions.Optimize(expr)).To(BeAssignableToTypeOf(&expressions.ExpressionFunction{}), s)
+			}
+		})
+
+		g.It("should solve like the expression it was built from", func() {
+			for _, s := range []string{
+				"a * 1", "0 + a", "1 * b", "a - 0 + b", "2 * 3 * a", "a * 2 * 3", "a / 1", "a ^ 1 + 0",
+				"1 - a", "0 - a", "-a", "7 / 2 * a", "flag || missing", "false && missing", "true xor flag",
+				"if(true, a, missing)", "if(false, missing, list[1])", "cos(0) * b", "0.1 + 0.2 == 0.3",
+				"a > 2 == true", "list[0 + 1]", "!(a > 1)", "(a)", "1 + 1.0",
+				"true ? a : missing", "0 ? missing : b * 1", "flag ? a + 0 : 2 * 3",
+				"\"x\" + \"y\" + name", "name + \"x\" + \"y\"", "a * 1 + 0 + a",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				expected, err := expr.Solve(ctx)
+				Expect(err).To(BeNil(), s)
+				v, err := expressions.Optimize(expr).Solve(ctx)
+				Expect(err).To(BeNil(), s)
+				Expect(v).To(Equal(expected), s)
+			}
+		})
+
+		g.It("should fail like the expression it was built from", func() {
+			for _, s := range []string{
+				"1 / 0", "a / (3 - 3)", "name * 1", "1 * name", "name + 0", "true + 1", "sqrt(1, 2, 3)",
+				"missing * 1", "list[1 + 5]", "\"john\".name", "if(true, 1 / 0, 2)", "false ? 1 : 1 / 0",
+				"name + 0 + name", "name * 1 + name", "name - 0", "\"x\" + \"y\" - a", "\"x\" * 1",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				_, expected := expr.Solve(ctx)
+				Expect(expected).NotTo(BeNil(), s)
+				_, err = expressions.Optimize(expr).Solve(ctx)
+				Expect(err).To(Equal(expected), s)
+			}
+		})
+
+		g.It("should keep what cannot be printed back to source", func() {
+			for s, expected := range map[string]string{
+				"true * 1":                          "true * 1",
+				"name + 0":                          "name + 0",
+				"split(\"a,b\", \",\")":             "split(\"a,b\", \",\")",
+				"len(split(\"a,b\", \",\")) * 1 + a": "len(split(\"a,b\", \",\")) * 1 + a",
+				"join(split(\"a,b\", \",\"), \"-\")": "join(split(\"a,b\", \",\"), \"-\")",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				Expect(expressions.Pretty(expressions.Optimize(expr))).To(Equal(expected), s)
+			}
+		})
+
+				g.It("should keep division by zero as a runtime error", func() {
+			expr, err := expressions.Compile("a + 1 / 0")
+			Expect(err).To(BeNil())
+			_, err = expressions.Optimize(expr).Solve(ctx)
+			var runtimeErr *expressions.RuntimeError
+			Expect(errors.As(err, &runtimeErr)).To(BeTrue())
+			Expect(runtimeErr.Span.Text()).To(Equal("1 / 0"))
+		})
+	})
+}
@@ -1,137 +1,264 @@
 package expressions
 
 import (
-	"github.com/jamillosantos/go-expressions/parser"
-	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"strconv"
-	"errors"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
 	"fmt"
+	"math"
 )
 
 type ExpressionError error
 
-func NewExpression(expression antlr.Tree) Expression {
-	switch e := expression.(type) {
-	case *parser.VariableContext:
-		return &ExpressionField{
-			field: e.GetText(),
-		}
-	case *parser.ScientificContext:
-		v, _ := strconv.ParseFloat(e.GetText(), 64)
-		return NewExpressionValue(v)
-	case *parser.AtomContext:
-		if e.GetChildCount() == 1 {
-			return NewExpression(e.GetChild(0))
-		} else {
-			return NewExpression(e.GetChild(1))
+var DefaultConstants = map[string]interface{}{
+	"pi": math.Pi,
+	"e":  math.E,
+	"i":  complex(0, 1),
+}
+
+type Compiler struct {
+	constants map[string]interface{}
+	maxDepth  int
+	maxChain  int
+	policy    *Policy
+}
+
+// NewCompiler returns a compiler initialized with the DefaultConstants, the
+// DefaultMaxDepth and the DefaultMaxChain.
+func NewCompiler() *Compiler {
+	constants := make(map[string]interface{}, len(DefaultConstants))
+	for name, value := range DefaultConstants {
+		constants[name] = value
+	}
+	return &Compiler{
+		constants: constants,
+		maxDepth:  DefaultMaxDepth,
+		maxChain:  DefaultMaxChain,
+	}
+}
+
+// SetConstant registers a named constant. Identifiers matching a constant are
+// replaced by its value at compile time and never reach the Resolver.
+func (c *Compiler) SetConstant(name string, value interface{}) *Compiler {
+	c.constants[name] = value
+	return c
+}
+
+func (c *Compiler) RemoveConstant(name string) *Compiler {
+	delete(c.constants, name)
+	return c
+}
+
+// SetMaxDepth sets how deeply expressions can be nested, counting parentheses,
+// unary operators, calls, indexes and conditionals. Chains of binary operators
+// are not nested, SetMaxChain limits them. Deeper expressions fail with a
+// LimitExceededError. Zero disables the limit.
+func (c *Compiler) SetMaxDepth(depth int) *Compiler {
+	c.maxDepth = depth
+	return c
+}
+
+// SetMaxChain sets how long chains of operators, members and indexes can be,
+// counting their operands and adding up the chains nested in one another, as
+// in `a + (b + c)`. Longer chains fail with a LimitExceededError. Zero
+// disables the limit.
+func (c *Compiler) SetMaxChain(chain int) *Compiler {
+	c.maxChain = chain
+	return c
+}
+
+// SetPolicy makes the compiler reject expressions that use functions or
+// variables the policy does not allow, failing with a PolicyError.
+func (c *Compiler) SetPolicy(policy *Policy) *Compiler {
+	c.policy = policy
+	return c
+}
+
+// validate fails when the expression built is nested or chained more than the
+// limits or when the policy does not allow it.
+func (c *Compiler) validate(expr Expression) error {
+	depth, chain := c.maxDepth, c.maxChain
+	if depth <= 0 {
+		depth = -1
+	}
+	if chain <= 0 {
+		chain = -1
+	}
+	switch exceedsDepth(expr, depth, chain) {
+	case LimitDepth:
+		return &LimitExceededError{Limit: LimitDepth, Max: c.maxDepth}
+	case LimitChain:
+		return &LimitExceededError{Limit: LimitChain, Max: c.maxChain}
+	}
+	if c.policy != nil {
+		return c.policy.Check(expr)
+	}
+	return nil
+}
+
+// variable returns the value of a constant or, when name is not a constant,
+// the field to be resolved.
+func (c *Compiler) variable(name string) Expression {
+	if v, ok := c.constants[name]; ok {
+		return &ExpressionValue{
+			value: v,
+			name:  name,
 		}
-	case *parser.SignedAtomContext:
-		if e.GetChildCount() == 1 {
-			return NewExpression(e.GetChild(0))
+	}
+	return &ExpressionField{
+		field: name,
+	}
+}
+
+// numberValue converts a number literal to int64 or, when it does not fit,
+// to uint64. Literals with fractions or exponents are float64.
+func numberValue(text string) interface{} {
+	if !strings.ContainsAny(text, ".eE") {
+		if v, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return v
 		}
-		expr := NewExpressionMultiple()
-		expr.Add("", NewExpressionValue(0))
-		expr.Add(e.GetOperator().GetText(), NewExpression(e.GetChild(1)))
-		return expr
-	case *parser.ExpressionContext:
-		if e.GetChildCount() == 1 {
-			return NewExpression(e.GetChild(0))
-		} else {
-			r := NewExpressionMultiple()
-			for i, me := range e.AllMultiplyingExpression() {
-				if i == 0 {
-					r.Add("", NewExpression(me))
-				} else {
-					r.Add(e.GetChild(i*2 - 1).(*antlr.TerminalNodeImpl).GetText(), NewExpression(me))
-				}
-			}
-			return r
+		if v, err := strconv.ParseUint(text, 10, 64); err == nil {
+			return v
 		}
-	case *parser.MultiplyingExpressionContext:
-		childCount := e.GetChildCount()
-		if childCount == 1 {
-			return NewExpression(e.GetChild(0))
-		} else {
-			r := NewExpressionMultiple()
-			for i, me := range e.AllPowExpression() {
-				if i == 0 {
-					r.Add("", NewExpression(me))
-				} else {
-					r.Add(e.GetChild(i*2 - 1).(*antlr.TerminalNodeImpl).GetText(), NewExpression(me))
-				}
-			}
-			return r
+	}
+	v, _ := strconv.ParseFloat(text, 64)
+	return v
+}
+
+// stringValue decodes a string literal, quoted with ' or ". The escape
+// sequences \n, \r, \t, \b, \f and \uXXXX are decoded, as are escaped quotes,
+// slashes and backslashes. Other backslashes are kept.
+func stringValue(text string) string {
+	body := text[1 : len(text)-1]
+	if !strings.ContainsRune(body, '\\') {
+		return body
+	}
+	var b strings.Builder
+	for i := 0; i < len(body); i++ {
+		if body[i] != '\\' || i+1 == len(body) {
+			b.WriteByte(body[i])
+			continue
 		}
-	case *parser.PowExpressionContext:
-		if e.GetChildCount() == 1 {
-			return NewExpression(e.GetChild(0))
-		} else {
-			r := NewExpressionMultiple()
-			for i, me := range e.AllSignedAtom() {
-				if i == 0 {
-					r.Add("", NewExpression(me))
-				} else {
-					r.Add(e.GetChild(i*2 - 1).(*antlr.TerminalNodeImpl).GetText(), NewExpression(me))
-				}
+		i++
+		switch c := body[i]; c {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case '"', '\'', '\\', '/':
+			b.WriteByte(c)
+		case 'u':
+			if r, n := unicodeEscape(body[i+1:]); n > 0 {
+				b.WriteRune(r)
+				i += n
+				break
 			}
-			return r
-		}
-	case *parser.FunctionContext:
-		eParams := e.AllExpression()
-		params := make([]Expression, 0, len(eParams))
-		for _, p := range eParams {
-			params = append(params, NewExpression(p))
+			fallthrough
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(c)
 		}
-		return NewExpressionFunction(e.GetFname().GetText(), params...)
-	case *parser.BinaryOpContext:
-		atoms := e.AllAtom()
-		return NewExpressionBinary(NewExpression(atoms[0]), e.Relop().GetText(), NewExpression(atoms[1]))
-	case *parser.StrContext:
-		str := e.GetText()
-		return NewExpressionValue(str[1:len(str)-1])
 	}
-	return nil
+	return b.String()
 }
 
-type CaptureErrorListener struct {
-	errors []error
+// unicodeEscape decodes the 4 hexadecimal digits of a \u escape, along with the
+// low surrogate that follows a high one, and returns how many bytes it used.
+func unicodeEscape(s string) (rune, int) {
+	if len(s) < 4 {
+		return 0, 0
+	}
+	v, err := strconv.ParseUint(s[:4], 16, 32)
+	if err != nil {
+		return 0, 0
+	}
+	r := rune(v)
+	if utf16.IsSurrogate(r) && len(s) >= 10 && s[4:6] == "\\u" {
+		if low, err := strconv.ParseUint(s[6:10], 16, 32); err == nil {
+			if pair := utf16.DecodeRune(r, rune(low)); pair != utf8.RuneError {
+				return pair, 10
+			}
+		}
+	}
+	return r, 4
 }
 
-func NewCaptureErrorListener() *CaptureErrorListener {
-	return &CaptureErrorListener{
-		errors: make([]error, 0),
-	}
+type SyntaxError struct {
+	Line      int
+	Column    int
+	Offending string
+	Expected  []string
+	Message   string
+	Snippet   string
 }
 
-func (errorListener *CaptureErrorListener) HasErrors() bool {
-	return len(errorListener.errors) > 0
+func (err *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at %d:%d", err.Message, err.Line, err.Column)
 }
 
-func (errorListener *CaptureErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
-	errorListener.errors = append(errorListener.errors, errors.New(fmt.Sprintf("%s at %d:%d", msg, line, column)))
+// CompileError holds all the syntax errors found while compiling Source.
+type CompileError struct {
+	Source string
+	Errors []*SyntaxError
 }
 
-func (errorListener *CaptureErrorListener) ReportAmbiguity(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex int, exact bool, ambigAlts *antlr.BitSet, configs antlr.ATNConfigSet) {
+func (err *CompileError) Error() string {
+	msgs := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
 }
 
-func (errorListener *CaptureErrorListener) ReportAttemptingFullContext(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex int, conflictingAlts *antlr.BitSet, configs antlr.ATNConfigSet) {
+// snippet returns the line of the source where the error happened followed by
+// a line with carets under the offending characters.
+func snippet(source string, line, column, length int) string {
+	lines := strings.Split(source, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	l := []rune(lines[line-1])
+	caret := make([]rune, 0, column+length)
+	for i := 0; i < column; i++ {
+		if i < len(l) && l[i] == '\t' {
+			caret = append(caret, '\t')
+		} else {
+			caret = append(caret, ' ')
+		}
+	}
+	for i := 0; i < length; i++ {
+		caret = append(caret, '^')
+	}
+	return string(l) + "\n" + string(caret)
 }
 
-func (errorListener *CaptureErrorListener) ReportContextSensitivity(recognizer antlr.Parser, dfa *antlr.DFA, startIndex, stopIndex, prediction int, configs antlr.ATNConfigSet) {
+// Compile parses an expression with the native parser, which accepts the
+// language described by antlr/Expression.g4.
+func (c *Compiler) Compile(expression string) (Expression, error) {
+	p := newDescentParser(c, expression)
+	expr := p.parse()
+	if p.limit != nil {
+		return nil, p.limit
+	}
+	if len(p.errors) > 0 {
+		return nil, &CompileError{
+			Source: expression,
+			Errors: p.errors,
+		}
+	}
+	if err := c.validate(expr); err != nil {
+		return nil, err
+	}
+	return expr, nil
 }
 
 func Compile(expression string) (Expression, error) {
-	input := antlr.NewInputStream(expression)
-	lexer := parser.NewExpressionLexer(input)
-	stream := antlr.NewCommonTokenStream(lexer, 0)
-	p := parser.NewExpressionParser(stream)
-
-	errorListener := NewCaptureErrorListener()
-	p.AddErrorListener(errorListener)
-	p.BuildParseTrees = true
-	expr := p.Expression()
-	if errorListener.HasErrors() {
-		return nil, errorListener.errors[0]
-	}
-	return NewExpression(expr), nil
+	return NewCompiler().Compile(expression)
 }
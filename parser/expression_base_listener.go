@@ -27,6 +27,42 @@ func (s *BaseExpressionListener) EnterExpression(ctx *ExpressionContext) {}
 // ExitExpression is called when production expression is exited.
 func (s *BaseExpressionListener) ExitExpression(ctx *ExpressionContext) {}
 
+// EnterOrExpression is called when production orExpression is entered.
+func (s *BaseExpressionListener) EnterOrExpression(ctx *OrExpressionContext) {}
+
+// ExitOrExpression is called when production orExpression is exited.
+func (s *BaseExpressionListener) ExitOrExpression(ctx *OrExpressionContext) {}
+
+// EnterXorExpression is called when production xorExpression is entered.
+func (s *BaseExpressionListener) EnterXorExpression(ctx *XorExpressionContext) {}
+
+// ExitXorExpression is called when production xorExpression is exited.
+func (s *BaseExpressionListener) ExitXorExpression(ctx *XorExpressionContext) {}
+
+// EnterAndExpression is called when production andExpression is entered.
+func (s *BaseExpressionListener) EnterAndExpression(ctx *AndExpressionContext) {}
+
+// ExitAndExpression is called when production andExpression is exited.
+func (s *BaseExpressionListener) ExitAndExpression(ctx *AndExpressionContext) {}
+
+// EnterEqualityExpression is called when production equalityExpression is entered.
+func (s *BaseExpressionListener) EnterEqualityExpression(ctx *EqualityExpressionContext) {}
+
+// ExitEqualityExpression is called when production equalityExpression is exited.
+func (s *BaseExpressionListener) ExitEqualityExpression(ctx *EqualityExpressionContext) {}
+
+// EnterRelationalExpression is called when production relationalExpression is entered.
+func (s *BaseExpressionListener) EnterRelationalExpression(ctx *RelationalExpressionContext) {}
+
+// ExitRelationalExpression is called when production relationalExpression is exited.
+func (s *BaseExpressionListener) ExitRelationalExpression(ctx *RelationalExpressionContext) {}
+
+// EnterAdditiveExpression is called when production additiveExpression is entered.
+func (s *BaseExpressionListener) EnterAdditiveExpression(ctx *AdditiveExpressionContext) {}
+
+// ExitAdditiveExpression is called when production additiveExpression is exited.
+func (s *BaseExpressionListener) ExitAdditiveExpression(ctx *AdditiveExpressionContext) {}
+
 // EnterMultiplyingExpression is called when production multiplyingExpression is entered.
 func (s *BaseExpressionListener) EnterMultiplyingExpression(ctx *MultiplyingExpressionContext) {}
 
@@ -45,11 +81,17 @@ func (s *BaseExpressionListener) EnterSignedAtom(ctx *SignedAtomContext) {}
 // ExitSignedAtom is called when production signedAtom is exited.
 func (s *BaseExpressionListener) ExitSignedAtom(ctx *SignedAtomContext) {}
 
-// EnterBinaryOp is called when production binaryOp is entered.
-func (s *BaseExpressionListener) EnterBinaryOp(ctx *BinaryOpContext) {}
+// EnterAccessor is called when production accessor is entered.
+func (s *BaseExpressionListener) EnterAccessor(ctx *AccessorContext) {}
+
+// ExitAccessor is called when production accessor is exited.
+func (s *BaseExpressionListener) ExitAccessor(ctx *AccessorContext) {}
 
-// ExitBinaryOp is called when production binaryOp is exited.
-func (s *BaseExpressionListener) ExitBinaryOp(ctx *BinaryOpContext) {}
+// EnterIdentifier is called when production identifier is entered.
+func (s *BaseExpressionListener) EnterIdentifier(ctx *IdentifierContext) {}
+
+// ExitIdentifier is called when production identifier is exited.
+func (s *BaseExpressionListener) ExitIdentifier(ctx *IdentifierContext) {}
 
 // EnterAtom is called when production atom is entered.
 func (s *BaseExpressionListener) EnterAtom(ctx *AtomContext) {}
@@ -69,6 +111,18 @@ func (s *BaseExpressionListener) EnterScientific(ctx *ScientificContext) {}
 // ExitScientific is called when production scientific is exited.
 func (s *BaseExpressionListener) ExitScientific(ctx *ScientificContext) {}
 
+// EnterBoolean is called when production boolean is entered.
+func (s *BaseExpressionListener) EnterBoolean(ctx *BooleanContext) {}
+
+// ExitBoolean is called when production boolean is exited.
+func (s *BaseExpressionListener) ExitBoolean(ctx *BooleanContext) {}
+
+// EnterNull is called when production null is entered.
+func (s *BaseExpressionListener) EnterNull(ctx *NullContext) {}
+
+// ExitNull is called when production null is exited.
+func (s *BaseExpressionListener) ExitNull(ctx *NullContext) {}
+
 // EnterConstant is called when production constant is entered.
 func (s *BaseExpressionListener) EnterConstant(ctx *ConstantContext) {}
 
@@ -86,9 +140,3 @@ func (s *BaseExpressionListener) EnterFunction(ctx *FunctionContext) {}
 
 // ExitFunction is called when production function is exited.
 func (s *BaseExpressionListener) ExitFunction(ctx *FunctionContext) {}
-
-// EnterRelop is called when production relop is entered.
-func (s *BaseExpressionListener) EnterRelop(ctx *RelopContext) {}
-
-// ExitRelop is called when production relop is exited.
-func (s *BaseExpressionListener) ExitRelop(ctx *RelopContext) {}
@@ -14,86 +14,114 @@ var _ = unicode.IsLetter
 
 
 var serializedLexerAtn = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 27, 181, 
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 37, 244, 
 	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 
 	9, 7, 4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 
 	4, 13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 
 	18, 9, 18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23, 
 	9, 23, 4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 4, 28, 9, 
 	28, 4, 29, 9, 29, 4, 30, 9, 30, 4, 31, 9, 31, 4, 32, 9, 32, 4, 33, 9, 33, 
-	3, 2, 3, 2, 3, 3, 3, 3, 3, 4, 3, 4, 3, 5, 3, 5, 3, 6, 3, 6, 3, 7, 3, 7, 
-	3, 8, 3, 8, 3, 9, 3, 9, 3, 10, 3, 10, 3, 11, 3, 11, 3, 11, 3, 12, 3, 12, 
-	3, 12, 3, 13, 3, 13, 3, 13, 3, 14, 3, 14, 3, 14, 3, 15, 3, 15, 3, 15, 3, 
-	15, 3, 16, 3, 16, 3, 17, 3, 17, 3, 18, 3, 18, 3, 19, 3, 19, 3, 19, 3, 20, 
-	3, 20, 3, 21, 3, 21, 3, 22, 3, 22, 7, 22, 117, 10, 22, 12, 22, 14, 22, 
-	120, 11, 22, 3, 23, 3, 23, 3, 23, 7, 23, 125, 10, 23, 12, 23, 14, 23, 128, 
-	11, 23, 3, 23, 3, 23, 3, 24, 3, 24, 3, 25, 3, 25, 3, 25, 3, 26, 5, 26, 
-	138, 10, 26, 3, 27, 3, 27, 5, 27, 142, 10, 27, 3, 28, 3, 28, 3, 28, 5, 
-	28, 147, 10, 28, 3, 28, 5, 28, 150, 10, 28, 3, 28, 3, 28, 5, 28, 154, 10, 
-	28, 3, 29, 6, 29, 157, 10, 29, 13, 29, 14, 29, 158, 3, 29, 3, 29, 6, 29, 
-	163, 10, 29, 13, 29, 14, 29, 164, 5, 29, 167, 10, 29, 3, 30, 3, 30, 3, 
-	31, 3, 31, 3, 32, 3, 32, 3, 33, 6, 33, 176, 10, 33, 13, 33, 14, 33, 177, 
-	3, 33, 3, 33, 3, 126, 2, 34, 3, 3, 5, 4, 7, 5, 9, 6, 11, 7, 13, 8, 15, 
-	9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 14, 27, 15, 29, 16, 31, 17, 33, 
-	18, 35, 19, 37, 20, 39, 21, 41, 22, 43, 23, 45, 24, 47, 25, 49, 2, 51, 
-	2, 53, 2, 55, 26, 57, 2, 59, 2, 61, 2, 63, 2, 65, 27, 3, 2, 6, 4, 2, 12, 
-	12, 15, 15, 5, 2, 67, 92, 97, 97, 99, 124, 4, 2, 45, 45, 47, 47, 5, 2, 
-	11, 12, 15, 15, 34, 34, 2, 184, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 
-	7, 3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 
-	2, 15, 3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 
-	2, 2, 23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 
-	2, 2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 
-	2, 2, 2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 
-	3, 2, 2, 2, 2, 47, 3, 2, 2, 2, 2, 55, 3, 2, 2, 2, 2, 65, 3, 2, 2, 2, 3, 
-	67, 3, 2, 2, 2, 5, 69, 3, 2, 2, 2, 7, 71, 3, 2, 2, 2, 9, 73, 3, 2, 2, 2, 
-	11, 75, 3, 2, 2, 2, 13, 77, 3, 2, 2, 2, 15, 79, 3, 2, 2, 2, 17, 81, 3, 
-	2, 2, 2, 19, 83, 3, 2, 2, 2, 21, 85, 3, 2, 2, 2, 23, 88, 3, 2, 2, 2, 25, 
-	91, 3, 2, 2, 2, 27, 94, 3, 2, 2, 2, 29, 97, 3, 2, 2, 2, 31, 101, 3, 2, 
-	2, 2, 33, 103, 3, 2, 2, 2, 35, 105, 3, 2, 2, 2, 37, 107, 3, 2, 2, 2, 39, 
-	110, 3, 2, 2, 2, 41, 112, 3, 2, 2, 2, 43, 114, 3, 2, 2, 2, 45, 121, 3, 
-	2, 2, 2, 47, 131, 3, 2, 2, 2, 49, 133, 3, 2, 2, 2, 51, 137, 3, 2, 2, 2, 
-	53, 141, 3, 2, 2, 2, 55, 143, 3, 2, 2, 2, 57, 156, 3, 2, 2, 2, 59, 168, 
-	3, 2, 2, 2, 61, 170, 3, 2, 2, 2, 63, 172, 3, 2, 2, 2, 65, 175, 3, 2, 2, 
-	2, 67, 68, 7, 42, 2, 2, 68, 4, 3, 2, 2, 2, 69, 70, 7, 43, 2, 2, 70, 6, 
-	3, 2, 2, 2, 71, 72, 7, 45, 2, 2, 72, 8, 3, 2, 2, 2, 73, 74, 7, 47, 2, 2, 
-	74, 10, 3, 2, 2, 2, 75, 76, 7, 44, 2, 2, 76, 12, 3, 2, 2, 2, 77, 78, 7, 
-	49, 2, 2, 78, 14, 3, 2, 2, 2, 79, 80, 7, 39, 2, 2, 80, 16, 3, 2, 2, 2, 
-	81, 82, 7, 64, 2, 2, 82, 18, 3, 2, 2, 2, 83, 84, 7, 62, 2, 2, 84, 20, 3, 
-	2, 2, 2, 85, 86, 7, 63, 2, 2, 86, 87, 7, 63, 2, 2, 87, 22, 3, 2, 2, 2, 
-	88, 89, 7, 35, 2, 2, 89, 90, 7, 63, 2, 2, 90, 24, 3, 2, 2, 2, 91, 92, 7, 
-	126, 2, 2, 92, 93, 7, 126, 2, 2, 93, 26, 3, 2, 2, 2, 94, 95, 7, 40, 2, 
-	2, 95, 96, 7, 40, 2, 2, 96, 28, 3, 2, 2, 2, 97, 98, 7, 122, 2, 2, 98, 99, 
-	7, 113, 2, 2, 99, 100, 7, 116, 2, 2, 100, 30, 3, 2, 2, 2, 101, 102, 7, 
-	46, 2, 2, 102, 32, 3, 2, 2, 2, 103, 104, 7, 48, 2, 2, 104, 34, 3, 2, 2, 
-	2, 105, 106, 7, 96, 2, 2, 106, 36, 3, 2, 2, 2, 107, 108, 7, 114, 2, 2, 
-	108, 109, 7, 107, 2, 2, 109, 38, 3, 2, 2, 2, 110, 111, 5, 61, 31, 2, 111, 
-	40, 3, 2, 2, 2, 112, 113, 7, 107, 2, 2, 113, 42, 3, 2, 2, 2, 114, 118, 
-	5, 51, 26, 2, 115, 117, 5, 53, 27, 2, 116, 115, 3, 2, 2, 2, 117, 120, 3, 
-	2, 2, 2, 118, 116, 3, 2, 2, 2, 118, 119, 3, 2, 2, 2, 119, 44, 3, 2, 2, 
-	2, 120, 118, 3, 2, 2, 2, 121, 126, 5, 47, 24, 2, 122, 125, 5, 49, 25, 2, 
-	123, 125, 10, 2, 2, 2, 124, 122, 3, 2, 2, 2, 124, 123, 3, 2, 2, 2, 125, 
-	128, 3, 2, 2, 2, 126, 127, 3, 2, 2, 2, 126, 124, 3, 2, 2, 2, 127, 129, 
-	3, 2, 2, 2, 128, 126, 3, 2, 2, 2, 129, 130, 5, 47, 24, 2, 130, 46, 3, 2, 
-	2, 2, 131, 132, 7, 36, 2, 2, 132, 48, 3, 2, 2, 2, 133, 134, 7, 94, 2, 2, 
-	134, 135, 7, 36, 2, 2, 135, 50, 3, 2, 2, 2, 136, 138, 9, 3, 2, 2, 137, 
-	136, 3, 2, 2, 2, 138, 52, 3, 2, 2, 2, 139, 142, 5, 51, 26, 2, 140, 142, 
-	4, 50, 59, 2, 141, 139, 3, 2, 2, 2, 141, 140, 3, 2, 2, 2, 142, 54, 3, 2, 
-	2, 2, 143, 153, 5, 57, 29, 2, 144, 147, 5, 59, 30, 2, 145, 147, 5, 61, 
-	31, 2, 146, 144, 3, 2, 2, 2, 146, 145, 3, 2, 2, 2, 147, 149, 3, 2, 2, 2, 
-	148, 150, 5, 63, 32, 2, 149, 148, 3, 2, 2, 2, 149, 150, 3, 2, 2, 2, 150, 
-	151, 3, 2, 2, 2, 151, 152, 5, 57, 29, 2, 152, 154, 3, 2, 2, 2, 153, 146, 
-	3, 2, 2, 2, 153, 154, 3, 2, 2, 2, 154, 56, 3, 2, 2, 2, 155, 157, 4, 50, 
-	59, 2, 156, 155, 3, 2, 2, 2, 157, 158, 3, 2, 2, 2, 158, 156, 3, 2, 2, 2, 
-	158, 159, 3, 2, 2, 2, 159, 166, 3, 2, 2, 2, 160, 162, 7, 48, 2, 2, 161, 
-	163, 4, 50, 59, 2, 162, 161, 3, 2, 2, 2, 163, 164, 3, 2, 2, 2, 164, 162, 
-	3, 2, 2, 2, 164, 165, 3, 2, 2, 2, 165, 167, 3, 2, 2, 2, 166, 160, 3, 2, 
-	2, 2, 166, 167, 3, 2, 2, 2, 167, 58, 3, 2, 2, 2, 168, 169, 7, 71, 2, 2, 
-	169, 60, 3, 2, 2, 2, 170, 171, 7, 103, 2, 2, 171, 62, 3, 2, 2, 2, 172, 
-	173, 9, 4, 2, 2, 173, 64, 3, 2, 2, 2, 174, 176, 9, 5, 2, 2, 175, 174, 3, 
-	2, 2, 2, 176, 177, 3, 2, 2, 2, 177, 175, 3, 2, 2, 2, 177, 178, 3, 2, 2, 
-	2, 178, 179, 3, 2, 2, 2, 179, 180, 8, 33, 2, 2, 180, 66, 3, 2, 2, 2, 15, 
-	2, 118, 124, 126, 137, 141, 146, 149, 153, 158, 164, 166, 177, 3, 8, 2, 
-	2,
+	4, 34, 9, 34, 4, 35, 9, 35, 4, 36, 9, 36, 4, 37, 9, 37, 4, 38, 9, 38, 4, 
+	39, 9, 39, 4, 40, 9, 40, 4, 41, 9, 41, 4, 42, 9, 42, 4, 43, 9, 43, 3, 2, 
+	3, 2, 3, 3, 3, 3, 3, 4, 3, 4, 3, 5, 3, 5, 3, 6, 3, 6, 3, 7, 3, 7, 3, 8, 
+	3, 8, 3, 9, 3, 9, 3, 10, 3, 10, 3, 11, 3, 11, 3, 12, 3, 12, 3, 13, 3, 13, 
+	3, 13, 3, 14, 3, 14, 3, 14, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 
+	17, 3, 17, 3, 18, 3, 18, 3, 18, 3, 19, 3, 19, 3, 19, 3, 20, 3, 20, 3, 20, 
+	3, 20, 3, 21, 3, 21, 3, 22, 3, 22, 3, 23, 3, 23, 3, 24, 3, 24, 3, 25, 3, 
+	25, 3, 26, 3, 26, 3, 26, 3, 27, 3, 27, 3, 28, 3, 28, 3, 29, 3, 29, 3, 29, 
+	3, 29, 3, 29, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 31, 3, 31, 3, 
+	31, 3, 31, 3, 31, 3, 32, 3, 32, 7, 32, 169, 10, 32, 12, 32, 14, 32, 172, 
+	11, 32, 3, 33, 3, 33, 3, 33, 7, 33, 177, 10, 33, 12, 33, 14, 33, 180, 11, 
+	33, 3, 33, 3, 33, 3, 33, 3, 33, 3, 33, 7, 33, 187, 10, 33, 12, 33, 14, 
+	33, 190, 11, 33, 3, 33, 5, 33, 193, 10, 33, 3, 34, 3, 34, 3, 35, 3, 35, 
+	3, 35, 3, 36, 5, 36, 201, 10, 36, 3, 37, 3, 37, 5, 37, 205, 10, 37, 3, 
+	38, 3, 38, 3, 38, 5, 38, 210, 10, 38, 3, 38, 5, 38, 213, 10, 38, 3, 38, 
+	3, 38, 5, 38, 217, 10, 38, 3, 39, 6, 39, 220, 10, 39, 13, 39, 14, 39, 221, 
+	3, 39, 3, 39, 6, 39, 226, 10, 39, 13, 39, 14, 39, 227, 5, 39, 230, 10, 
+	39, 3, 40, 3, 40, 3, 41, 3, 41, 3, 42, 3, 42, 3, 43, 6, 43, 239, 10, 43, 
+	13, 43, 14, 43, 240, 3, 43, 3, 43, 2, 2, 44, 3, 3, 5, 4, 7, 5, 9, 6, 11, 
+	7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 14, 27, 15, 29, 16, 
+	31, 17, 33, 18, 35, 19, 37, 20, 39, 21, 41, 22, 43, 23, 45, 24, 47, 25, 
+	49, 26, 51, 27, 53, 28, 55, 29, 57, 30, 59, 31, 61, 32, 63, 33, 65, 34, 
+	67, 35, 69, 2, 71, 2, 73, 2, 75, 36, 77, 2, 79, 2, 81, 2, 83, 2, 85, 37, 
+	3, 2, 8, 6, 2, 12, 12, 15, 15, 36, 36, 94, 94, 6, 2, 12, 12, 15, 15, 41, 
+	41, 94, 94, 4, 2, 12, 12, 15, 15, 5, 2, 67, 92, 97, 97, 99, 124, 4, 2, 
+	45, 45, 47, 47, 5, 2, 11, 12, 15, 15, 34, 34, 2, 250, 2, 3, 3, 2, 2, 2, 
+	2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 
+	2, 13, 3, 2, 2, 2, 2, 15, 3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 
+	2, 2, 21, 3, 2, 2, 2, 2, 23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 
+	2, 2, 2, 29, 3, 2, 2, 2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 
+	2, 2, 2, 2, 37, 3, 2, 2, 2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 2, 43, 
+	3, 2, 2, 2, 2, 45, 3, 2, 2, 2, 2, 47, 3, 2, 2, 2, 2, 49, 3, 2, 2, 2, 2, 
+	51, 3, 2, 2, 2, 2, 53, 3, 2, 2, 2, 2, 55, 3, 2, 2, 2, 2, 57, 3, 2, 2, 2, 
+	2, 59, 3, 2, 2, 2, 2, 61, 3, 2, 2, 2, 2, 63, 3, 2, 2, 2, 2, 65, 3, 2, 2, 
+	2, 2, 67, 3, 2, 2, 2, 2, 75, 3, 2, 2, 2, 2, 85, 3, 2, 2, 2, 3, 87, 3, 2, 
+	2, 2, 5, 89, 3, 2, 2, 2, 7, 91, 3, 2, 2, 2, 9, 93, 3, 2, 2, 2, 11, 95, 
+	3, 2, 2, 2, 13, 97, 3, 2, 2, 2, 15, 99, 3, 2, 2, 2, 17, 101, 3, 2, 2, 2, 
+	19, 103, 3, 2, 2, 2, 21, 105, 3, 2, 2, 2, 23, 107, 3, 2, 2, 2, 25, 109, 
+	3, 2, 2, 2, 27, 112, 3, 2, 2, 2, 29, 115, 3, 2, 2, 2, 31, 118, 3, 2, 2, 
+	2, 33, 121, 3, 2, 2, 2, 35, 123, 3, 2, 2, 2, 37, 126, 3, 2, 2, 2, 39, 129, 
+	3, 2, 2, 2, 41, 133, 3, 2, 2, 2, 43, 135, 3, 2, 2, 2, 45, 137, 3, 2, 2, 
+	2, 47, 139, 3, 2, 2, 2, 49, 141, 3, 2, 2, 2, 51, 143, 3, 2, 2, 2, 53, 146, 
+	3, 2, 2, 2, 55, 148, 3, 2, 2, 2, 57, 150, 3, 2, 2, 2, 59, 155, 3, 2, 2, 
+	2, 61, 161, 3, 2, 2, 2, 63, 166, 3, 2, 2, 2, 65, 192, 3, 2, 2, 2, 67, 194, 
+	3, 2, 2, 2, 69, 196, 3, 2, 2, 2, 71, 200, 3, 2, 2, 2, 73, 204, 3, 2, 2, 
+	2, 75, 206, 3, 2, 2, 2, 77, 219, 3, 2, 2, 2, 79, 231, 3, 2, 2, 2, 81, 233, 
+	3, 2, 2, 2, 83, 235, 3, 2, 2, 2, 85, 238, 3, 2, 2, 2, 87, 88, 7, 42, 2, 
+	2, 88, 4, 3, 2, 2, 2, 89, 90, 7, 43, 2, 2, 90, 6, 3, 2, 2, 2, 91, 92, 7, 
+	93, 2, 2, 92, 8, 3, 2, 2, 2, 93, 94, 7, 95, 2, 2, 94, 10, 3, 2, 2, 2, 95, 
+	96, 7, 45, 2, 2, 96, 12, 3, 2, 2, 2, 97, 98, 7, 47, 2, 2, 98, 14, 3, 2, 
+	2, 2, 99, 100, 7, 44, 2, 2, 100, 16, 3, 2, 2, 2, 101, 102, 7, 49, 2, 2, 
+	102, 18, 3, 2, 2, 2, 103, 104, 7, 39, 2, 2, 104, 20, 3, 2, 2, 2, 105, 106, 
+	7, 64, 2, 2, 106, 22, 3, 2, 2, 2, 107, 108, 7, 62, 2, 2, 108, 24, 3, 2, 
+	2, 2, 109, 110, 7, 64, 2, 2, 110, 111, 7, 63, 2, 2, 111, 26, 3, 2, 2, 2, 
+	112, 113, 7, 62, 2, 2, 113, 114, 7, 63, 2, 2, 114, 28, 3, 2, 2, 2, 115, 
+	116, 7, 63, 2, 2, 116, 117, 7, 63, 2, 2, 117, 30, 3, 2, 2, 2, 118, 119, 
+	7, 35, 2, 2, 119, 120, 7, 63, 2, 2, 120, 32, 3, 2, 2, 2, 121, 122, 7, 35, 
+	2, 2, 122, 34, 3, 2, 2, 2, 123, 124, 7, 126, 2, 2, 124, 125, 7, 126, 2, 
+	2, 125, 36, 3, 2, 2, 2, 126, 127, 7, 40, 2, 2, 127, 128, 7, 40, 2, 2, 128, 
+	38, 3, 2, 2, 2, 129, 130, 7, 122, 2, 2, 130, 131, 7, 113, 2, 2, 131, 132, 
+	7, 116, 2, 2, 132, 40, 3, 2, 2, 2, 133, 134, 7, 46, 2, 2, 134, 42, 3, 2, 
+	2, 2, 135, 136, 7, 48, 2, 2, 136, 44, 3, 2, 2, 2, 137, 138, 7, 96, 2, 2, 
+	138, 46, 3, 2, 2, 2, 139, 140, 7, 65, 2, 2, 140, 48, 3, 2, 2, 2, 141, 142, 
+	7, 60, 2, 2, 142, 50, 3, 2, 2, 2, 143, 144, 7, 114, 2, 2, 144, 145, 7, 
+	107, 2, 2, 145, 52, 3, 2, 2, 2, 146, 147, 5, 81, 41, 2, 147, 54, 3, 2, 
+	2, 2, 148, 149, 7, 107, 2, 2, 149, 56, 3, 2, 2, 2, 150, 151, 7, 118, 2, 
+	2, 151, 152, 7, 116, 2, 2, 152, 153, 7, 119, 2, 2, 153, 154, 7, 103, 2, 
+	2, 154, 58, 3, 2, 2, 2, 155, 156, 7, 104, 2, 2, 156, 157, 7, 99, 2, 2, 
+	157, 158, 7, 110, 2, 2, 158, 159, 7, 117, 2, 2, 159, 160, 7, 103, 2, 2, 
+	160, 60, 3, 2, 2, 2, 161, 162, 7, 112, 2, 2, 162, 163, 7, 119, 2, 2, 163, 
+	164, 7, 110, 2, 2, 164, 165, 7, 110, 2, 2, 165, 62, 3, 2, 2, 2, 166, 170, 
+	5, 71, 36, 2, 167, 169, 5, 73, 37, 2, 168, 167, 3, 2, 2, 2, 169, 172, 3, 
+	2, 2, 2, 170, 168, 3, 2, 2, 2, 170, 171, 3, 2, 2, 2, 171, 64, 3, 2, 2, 
+	2, 172, 170, 3, 2, 2, 2, 173, 178, 5, 67, 34, 2, 174, 177, 5, 69, 35, 2, 
+	175, 177, 10, 2, 2, 2, 176, 174, 3, 2, 2, 2, 176, 175, 3, 2, 2, 2, 177, 
+	180, 3, 2, 2, 2, 178, 176, 3, 2, 2, 2, 178, 179, 3, 2, 2, 2, 179, 181, 
+	3, 2, 2, 2, 180, 178, 3, 2, 2, 2, 181, 182, 5, 67, 34, 2, 182, 193, 3, 
+	2, 2, 2, 183, 188, 7, 41, 2, 2, 184, 187, 5, 69, 35, 2, 185, 187, 10, 3, 
+	2, 2, 186, 184, 3, 2, 2, 2, 186, 185, 3, 2, 2, 2, 187, 190, 3, 2, 2, 2, 
+	188, 186, 3, 2, 2, 2, 188, 189, 3, 2, 2, 2, 189, 191, 3, 2, 2, 2, 190, 
+	188, 3, 2, 2, 2, 191, 193, 7, 41, 2, 2, 192, 173, 3, 2, 2, 2, 192, 183, 
+	3, 2, 2, 2, 193, 66, 3, 2, 2, 2, 194, 195, 7, 36, 2, 2, 195, 68, 3, 2, 
+	2, 2, 196, 197, 7, 94, 2, 2, 197, 198, 10, 4, 2, 2, 198, 70, 3, 2, 2, 2, 
+	199, 201, 9, 5, 2, 2, 200, 199, 3, 2, 2, 2, 201, 72, 3, 2, 2, 2, 202, 205, 
+	5, 71, 36, 2, 203, 205, 4, 50, 59, 2, 204, 202, 3, 2, 2, 2, 204, 203, 3, 
+	2, 2, 2, 205, 74, 3, 2, 2, 2, 206, 216, 5, 77, 39, 2, 207, 210, 5, 79, 
+	40, 2, 208, 210, 5, 81, 41, 2, 209, 207, 3, 2, 2, 2, 209, 208, 3, 2, 2, 
+	2, 210, 212, 3, 2, 2, 2, 211, 213, 5, 83, 42, 2, 212, 211, 3, 2, 2, 2, 
+	212, 213, 3, 2, 2, 2, 213, 214, 3, 2, 2, 2, 214, 215, 5, 77, 39, 2, 215, 
+	217, 3, 2, 2, 2, 216, 209, 3, 2, 2, 2, 216, 217, 3, 2, 2, 2, 217, 76, 3, 
+	2, 2, 2, 218, 220, 4, 50, 59, 2, 219, 218, 3, 2, 2, 2, 220, 221, 3, 2, 
+	2, 2, 221, 219, 3, 2, 2, 2, 221, 222, 3, 2, 2, 2, 222, 229, 3, 2, 2, 2, 
+	223, 225, 7, 48, 2, 2, 224, 226, 4, 50, 59, 2, 225, 224, 3, 2, 2, 2, 226, 
+	227, 3, 2, 2, 2, 227, 225, 3, 2, 2, 2, 227, 228, 3, 2, 2, 2, 228, 230, 
+	3, 2, 2, 2, 229, 223, 3, 2, 2, 2, 229, 230, 3, 2, 2, 2, 230, 78, 3, 2, 
+	2, 2, 231, 232, 7, 71, 2, 2, 232, 80, 3, 2, 2, 2, 233, 234, 7, 103, 2, 
+	2, 234, 82, 3, 2, 2, 2, 235, 236, 9, 6, 2, 2, 236, 84, 3, 2, 2, 2, 237, 
+	239, 9, 7, 2, 2, 238, 237, 3, 2, 2, 2, 239, 240, 3, 2, 2, 2, 240, 238, 
+	3, 2, 2, 2, 240, 241, 3, 2, 2, 2, 241, 242, 3, 2, 2, 2, 242, 243, 8, 43, 
+	2, 2, 243, 86, 3, 2, 2, 2, 18, 2, 170, 176, 178, 186, 188, 192, 200, 204, 
+	209, 212, 216, 221, 227, 229, 240, 3, 8, 2, 2,
 }
 
 var lexerDeserializer = antlr.NewATNDeserializer(nil)
@@ -108,21 +136,25 @@ var lexerModeNames = []string{
 }
 
 var lexerLiteralNames = []string{
-	"", "'('", "')'", "'+'", "'-'", "'*'", "'/'", "'%'", "'>'", "'<'", "'=='", 
-	"'!='", "'||'", "'&&'", "'xor'", "','", "'.'", "'^'", "'pi'", "", "'i'", 
-	"", "", "'\"'",
+	"", "'('", "')'", "'['", "']'", "'+'", "'-'", "'*'", "'/'", "'%'", "'>'", 
+	"'<'", "'>='", "'<='", "'=='", "'!='", "'!'", "'||'", "'&&'", "'xor'", 
+	"','", "'.'", "'^'", "'?'", "':'", "'pi'", "", "'i'", "'true'", "'false'", 
+	"'null'", "", "", "'\"'",
 }
 
 var lexerSymbolicNames = []string{
-	"", "LPAREN", "RPAREN", "PLUS", "MINUS", "TIMES", "DIV", "MOD", "GT", "LT", 
-	"EQ", "NOT_EQ", "OR", "AND", "XOR", "COMMA", "POINT", "POW", "PI", "EULER", 
-	"I", "VARIABLE", "QUOTED_STRING", "QUOTE", "SCIENTIFIC_NUMBER", "WS",
+	"", "LPAREN", "RPAREN", "LBRACKET", "RBRACKET", "PLUS", "MINUS", "TIMES", 
+	"DIV", "MOD", "GT", "LT", "GTE", "LTE", "EQ", "NOT_EQ", "NOT", "OR", "AND", 
+	"XOR", "COMMA", "POINT", "POW", "QUESTION", "COLON", "PI", "EULER", "I", 
+	"TRUE", "FALSE", "NULL", "VARIABLE", "QUOTED_STRING", "QUOTE", "SCIENTIFIC_NUMBER", 
+	"WS",
 }
 
 var lexerRuleNames = []string{
-	"LPAREN", "RPAREN", "PLUS", "MINUS", "TIMES", "DIV", "MOD", "GT", "LT", 
-	"EQ", "NOT_EQ", "OR", "AND", "XOR", "COMMA", "POINT", "POW", "PI", "EULER", 
-	"I", "VARIABLE", "QUOTED_STRING", "QUOTE", "ESCAPED_QUOTE", "VALID_ID_START", 
+	"LPAREN", "RPAREN", "LBRACKET", "RBRACKET", "PLUS", "MINUS", "TIMES", "DIV", 
+	"MOD", "GT", "LT", "GTE", "LTE", "EQ", "NOT_EQ", "NOT", "OR", "AND", "XOR", 
+	"COMMA", "POINT", "POW", "QUESTION", "COLON", "PI", "EULER", "I", "TRUE", 
+	"FALSE", "NULL", "VARIABLE", "QUOTED_STRING", "QUOTE", "ESCAPE", "VALID_ID_START", 
 	"VALID_ID_CHAR", "SCIENTIFIC_NUMBER", "NUMBER", "E1", "E2", "SIGN", "WS",
 }
 
@@ -163,28 +195,38 @@ func NewExpressionLexer(input antlr.CharStream) *ExpressionLexer {
 const (
 	ExpressionLexerLPAREN = 1
 	ExpressionLexerRPAREN = 2
-	ExpressionLexerPLUS = 3
-	ExpressionLexerMINUS = 4
-	ExpressionLexerTIMES = 5
-	ExpressionLexerDIV = 6
-	ExpressionLexerMOD = 7
-	ExpressionLexerGT = 8
-	ExpressionLexerLT = 9
-	ExpressionLexerEQ = 10
-	ExpressionLexerNOT_EQ = 11
-	ExpressionLexerOR = 12
-	ExpressionLexerAND = 13
-	ExpressionLexerXOR = 14
-	ExpressionLexerCOMMA = 15
-	ExpressionLexerPOINT = 16
-	ExpressionLexerPOW = 17
-	ExpressionLexerPI = 18
-	ExpressionLexerEULER = 19
-	ExpressionLexerI = 20
-	ExpressionLexerVARIABLE = 21
-	ExpressionLexerQUOTED_STRING = 22
-	ExpressionLexerQUOTE = 23
-	ExpressionLexerSCIENTIFIC_NUMBER = 24
-	ExpressionLexerWS = 25
+	ExpressionLexerLBRACKET = 3
+	ExpressionLexerRBRACKET = 4
+	ExpressionLexerPLUS = 5
+	ExpressionLexerMINUS = 6
+	ExpressionLexerTIMES = 7
+	ExpressionLexerDIV = 8
+	ExpressionLexerMOD = 9
+	ExpressionLexerGT = 10
+	ExpressionLexerLT = 11
+	ExpressionLexerGTE = 12
+	ExpressionLexerLTE = 13
+	ExpressionLexerEQ = 14
+	ExpressionLexerNOT_EQ = 15
+	ExpressionLexerNOT = 16
+	ExpressionLexerOR = 17
+	ExpressionLexerAND = 18
+	ExpressionLexerXOR = 19
+	ExpressionLexerCOMMA = 20
+	ExpressionLexerPOINT = 21
+	ExpressionLexerPOW = 22
+	ExpressionLexerQUESTION = 23
+	ExpressionLexerCOLON = 24
+	ExpressionLexerPI = 25
+	ExpressionLexerEULER = 26
+	ExpressionLexerI = 27
+	ExpressionLexerTRUE = 28
+	ExpressionLexerFALSE = 29
+	ExpressionLexerNULL = 30
+	ExpressionLexerVARIABLE = 31
+	ExpressionLexerQUOTED_STRING = 32
+	ExpressionLexerQUOTE = 33
+	ExpressionLexerSCIENTIFIC_NUMBER = 34
+	ExpressionLexerWS = 35
 )
 
@@ -11,6 +11,24 @@ type ExpressionListener interface {
 	// EnterExpression is called when entering the expression production.
 	EnterExpression(c *ExpressionContext)
 
+	// EnterOrExpression is called when entering the orExpression production.
+	EnterOrExpression(c *OrExpressionContext)
+
+	// EnterXorExpression is called when entering the xorExpression production.
+	EnterXorExpression(c *XorExpressionContext)
+
+	// EnterAndExpression is called when entering the andExpression production.
+	EnterAndExpression(c *AndExpressionContext)
+
+	// EnterEqualityExpression is called when entering the equalityExpression production.
+	EnterEqualityExpression(c *EqualityExpressionContext)
+
+	// EnterRelationalExpression is called when entering the relationalExpression production.
+	EnterRelationalExpression(c *RelationalExpressionContext)
+
+	// EnterAdditiveExpression is called when entering the additiveExpression production.
+	EnterAdditiveExpression(c *AdditiveExpressionContext)
+
 	// EnterMultiplyingExpression is called when entering the multiplyingExpression production.
 	EnterMultiplyingExpression(c *MultiplyingExpressionContext)
 
@@ -20,8 +38,11 @@ type ExpressionListener interface {
 	// EnterSignedAtom is called when entering the signedAtom production.
 	EnterSignedAtom(c *SignedAtomContext)
 
-	// EnterBinaryOp is called when entering the binaryOp production.
-	EnterBinaryOp(c *BinaryOpContext)
+	// EnterAccessor is called when entering the accessor production.
+	EnterAccessor(c *AccessorContext)
+
+	// EnterIdentifier is called when entering the identifier production.
+	EnterIdentifier(c *IdentifierContext)
 
 	// EnterAtom is called when entering the atom production.
 	EnterAtom(c *AtomContext)
@@ -32,6 +53,12 @@ type ExpressionListener interface {
 	// EnterScientific is called when entering the scientific production.
 	EnterScientific(c *ScientificContext)
 
+	// EnterBoolean is called when entering the boolean production.
+	EnterBoolean(c *BooleanContext)
+
+	// EnterNull is called when entering the null production.
+	EnterNull(c *NullContext)
+
 	// EnterConstant is called when entering the constant production.
 	EnterConstant(c *ConstantContext)
 
@@ -41,12 +68,27 @@ type ExpressionListener interface {
 	// EnterFunction is called when entering the function production.
 	EnterFunction(c *FunctionContext)
 
-	// EnterRelop is called when entering the relop production.
-	EnterRelop(c *RelopContext)
-
 	// ExitExpression is called when exiting the expression production.
 	ExitExpression(c *ExpressionContext)
 
+	// ExitOrExpression is called when exiting the orExpression production.
+	ExitOrExpression(c *OrExpressionContext)
+
+	// ExitXorExpression is called when exiting the xorExpression production.
+	ExitXorExpression(c *XorExpressionContext)
+
+	// ExitAndExpression is called when exiting the andExpression production.
+	ExitAndExpression(c *AndExpressionContext)
+
+	// ExitEqualityExpression is called when exiting the equalityExpression production.
+	ExitEqualityExpression(c *EqualityExpressionContext)
+
+	// ExitRelationalExpression is called when exiting the relationalExpression production.
+	ExitRelationalExpression(c *RelationalExpressionContext)
+
+	// ExitAdditiveExpression is called when exiting the additiveExpression production.
+	ExitAdditiveExpression(c *AdditiveExpressionContext)
+
 	// ExitMultiplyingExpression is called when exiting the multiplyingExpression production.
 	ExitMultiplyingExpression(c *MultiplyingExpressionContext)
 
@@ -56,8 +98,11 @@ type ExpressionListener interface {
 	// ExitSignedAtom is called when exiting the signedAtom production.
 	ExitSignedAtom(c *SignedAtomContext)
 
-	// ExitBinaryOp is called when exiting the binaryOp production.
-	ExitBinaryOp(c *BinaryOpContext)
+	// ExitAccessor is called when exiting the accessor production.
+	ExitAccessor(c *AccessorContext)
+
+	// ExitIdentifier is called when exiting the identifier production.
+	ExitIdentifier(c *IdentifierContext)
 
 	// ExitAtom is called when exiting the atom production.
 	ExitAtom(c *AtomContext)
@@ -68,6 +113,12 @@ type ExpressionListener interface {
 	// ExitScientific is called when exiting the scientific production.
 	ExitScientific(c *ScientificContext)
 
+	// ExitBoolean is called when exiting the boolean production.
+	ExitBoolean(c *BooleanContext)
+
+	// ExitNull is called when exiting the null production.
+	ExitNull(c *NullContext)
+
 	// ExitConstant is called when exiting the constant production.
 	ExitConstant(c *ConstantContext)
 
@@ -76,7 +127,4 @@ type ExpressionListener interface {
 
 	// ExitFunction is called when exiting the function production.
 	ExitFunction(c *FunctionContext)
-
-	// ExitRelop is called when exiting the relop production.
-	ExitRelop(c *RelopContext)
 }
@@ -17,62 +17,105 @@ var _ = strconv.Itoa
 
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 27, 94, 4, 
-	2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7, 4, 
-	8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13, 9, 
-	13, 3, 2, 3, 2, 3, 2, 7, 2, 30, 10, 2, 12, 2, 14, 2, 33, 11, 2, 3, 3, 3, 
-	3, 3, 3, 7, 3, 38, 10, 3, 12, 3, 14, 3, 41, 11, 3, 3, 4, 3, 4, 3, 4, 7, 
-	4, 46, 10, 4, 12, 4, 14, 4, 49, 11, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 5, 
-	5, 56, 10, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 7, 3, 7, 3, 7, 3, 7, 3, 7, 3, 
-	7, 3, 7, 3, 7, 5, 7, 70, 10, 7, 3, 8, 3, 8, 3, 9, 3, 9, 3, 10, 3, 10, 3, 
-	11, 3, 11, 3, 12, 3, 12, 3, 12, 3, 12, 3, 12, 7, 12, 85, 10, 12, 12, 12, 
-	14, 12, 88, 11, 12, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13, 2, 2, 14, 2, 4, 
-	6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 2, 6, 3, 2, 5, 6, 3, 2, 7, 9, 3, 
-	2, 20, 22, 3, 2, 10, 16, 2, 92, 2, 26, 3, 2, 2, 2, 4, 34, 3, 2, 2, 2, 6, 
-	42, 3, 2, 2, 2, 8, 55, 3, 2, 2, 2, 10, 57, 3, 2, 2, 2, 12, 69, 3, 2, 2, 
-	2, 14, 71, 3, 2, 2, 2, 16, 73, 3, 2, 2, 2, 18, 75, 3, 2, 2, 2, 20, 77, 
-	3, 2, 2, 2, 22, 79, 3, 2, 2, 2, 24, 91, 3, 2, 2, 2, 26, 31, 5, 4, 3, 2, 
-	27, 28, 9, 2, 2, 2, 28, 30, 5, 4, 3, 2, 29, 27, 3, 2, 2, 2, 30, 33, 3, 
-	2, 2, 2, 31, 29, 3, 2, 2, 2, 31, 32, 3, 2, 2, 2, 32, 3, 3, 2, 2, 2, 33, 
-	31, 3, 2, 2, 2, 34, 39, 5, 6, 4, 2, 35, 36, 9, 3, 2, 2, 36, 38, 5, 6, 4, 
-	2, 37, 35, 3, 2, 2, 2, 38, 41, 3, 2, 2, 2, 39, 37, 3, 2, 2, 2, 39, 40, 
-	3, 2, 2, 2, 40, 5, 3, 2, 2, 2, 41, 39, 3, 2, 2, 2, 42, 47, 5, 8, 5, 2, 
-	43, 44, 7, 19, 2, 2, 44, 46, 5, 8, 5, 2, 45, 43, 3, 2, 2, 2, 46, 49, 3, 
-	2, 2, 2, 47, 45, 3, 2, 2, 2, 47, 48, 3, 2, 2, 2, 48, 7, 3, 2, 2, 2, 49, 
-	47, 3, 2, 2, 2, 50, 51, 9, 2, 2, 2, 51, 56, 5, 8, 5, 2, 52, 56, 5, 22, 
-	12, 2, 53, 56, 5, 12, 7, 2, 54, 56, 5, 10, 6, 2, 55, 50, 3, 2, 2, 2, 55, 
-	52, 3, 2, 2, 2, 55, 53, 3, 2, 2, 2, 55, 54, 3, 2, 2, 2, 56, 9, 3, 2, 2, 
-	2, 57, 58, 5, 12, 7, 2, 58, 59, 5, 24, 13, 2, 59, 60, 5, 12, 7, 2, 60, 
-	11, 3, 2, 2, 2, 61, 70, 5, 16, 9, 2, 62, 70, 5, 20, 11, 2, 63, 70, 5, 18, 
-	10, 2, 64, 65, 7, 3, 2, 2, 65, 66, 5, 2, 2, 2, 66, 67, 7, 4, 2, 2, 67, 
-	70, 3, 2, 2, 2, 68, 70, 5, 14, 8, 2, 69, 61, 3, 2, 2, 2, 69, 62, 3, 2, 
-	2, 2, 69, 63, 3, 2, 2, 2, 69, 64, 3, 2, 2, 2, 69, 68, 3, 2, 2, 2, 70, 13, 
-	3, 2, 2, 2, 71, 72, 7, 24, 2, 2, 72, 15, 3, 2, 2, 2, 73, 74, 7, 26, 2, 
-	2, 74, 17, 3, 2, 2, 2, 75, 76, 9, 4, 2, 2, 76, 19, 3, 2, 2, 2, 77, 78, 
-	7, 23, 2, 2, 78, 21, 3, 2, 2, 2, 79, 80, 7, 23, 2, 2, 80, 81, 7, 3, 2, 
-	2, 81, 86, 5, 2, 2, 2, 82, 83, 7, 17, 2, 2, 83, 85, 5, 2, 2, 2, 84, 82, 
-	3, 2, 2, 2, 85, 88, 3, 2, 2, 2, 86, 84, 3, 2, 2, 2, 86, 87, 3, 2, 2, 2, 
-	87, 89, 3, 2, 2, 2, 88, 86, 3, 2, 2, 2, 89, 90, 7, 4, 2, 2, 90, 23, 3, 
-	2, 2, 2, 91, 92, 9, 5, 2, 2, 92, 25, 3, 2, 2, 2, 8, 31, 39, 47, 55, 69, 
-	86,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 37, 179, 
+	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7, 
+	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13, 
+	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9, 
+	18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 3, 2, 3, 2, 3, 2, 3, 2, 3, 
+	2, 3, 2, 5, 2, 49, 10, 2, 3, 3, 3, 3, 3, 3, 7, 3, 54, 10, 3, 12, 3, 14, 
+	3, 57, 11, 3, 3, 4, 3, 4, 3, 4, 7, 4, 62, 10, 4, 12, 4, 14, 4, 65, 11, 
+	4, 3, 5, 3, 5, 3, 5, 7, 5, 70, 10, 5, 12, 5, 14, 5, 73, 11, 5, 3, 6, 3, 
+	6, 3, 6, 7, 6, 78, 10, 6, 12, 6, 14, 6, 81, 11, 6, 3, 7, 3, 7, 3, 7, 7, 
+	7, 86, 10, 7, 12, 7, 14, 7, 89, 11, 7, 3, 8, 3, 8, 3, 8, 7, 8, 94, 10, 
+	8, 12, 8, 14, 8, 97, 11, 8, 3, 9, 3, 9, 3, 9, 7, 9, 102, 10, 9, 12, 9, 
+	14, 9, 105, 11, 9, 3, 10, 3, 10, 3, 10, 7, 10, 110, 10, 10, 12, 10, 14, 
+	10, 113, 11, 10, 3, 11, 3, 11, 3, 11, 3, 11, 7, 11, 119, 10, 11, 12, 11, 
+	14, 11, 122, 11, 11, 3, 11, 3, 11, 7, 11, 126, 10, 11, 12, 11, 14, 11, 
+	129, 11, 11, 5, 11, 131, 10, 11, 3, 12, 3, 12, 3, 12, 3, 12, 3, 12, 3, 
+	12, 5, 12, 139, 10, 12, 3, 13, 3, 13, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 
+	3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 5, 14, 153, 10, 14, 3, 15, 3, 15, 3, 
+	16, 3, 16, 3, 17, 3, 17, 3, 18, 3, 18, 3, 19, 3, 19, 3, 20, 3, 20, 3, 21, 
+	3, 21, 3, 21, 3, 21, 3, 21, 7, 21, 172, 10, 21, 12, 21, 14, 21, 175, 11, 
+	21, 3, 21, 3, 21, 3, 21, 2, 2, 22, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 
+	22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 2, 10, 3, 2, 16, 17, 3, 2, 12, 
+	15, 3, 2, 7, 8, 3, 2, 9, 11, 4, 2, 7, 8, 18, 18, 3, 2, 27, 33, 3, 2, 30, 
+	31, 3, 2, 27, 29, 2, 179, 2, 42, 3, 2, 2, 2, 4, 50, 3, 2, 2, 2, 6, 58, 
+	3, 2, 2, 2, 8, 66, 3, 2, 2, 2, 10, 74, 3, 2, 2, 2, 12, 82, 3, 2, 2, 2, 
+	14, 90, 3, 2, 2, 2, 16, 98, 3, 2, 2, 2, 18, 106, 3, 2, 2, 2, 20, 130, 3, 
+	2, 2, 2, 22, 138, 3, 2, 2, 2, 24, 140, 3, 2, 2, 2, 26, 152, 3, 2, 2, 2, 
+	28, 154, 3, 2, 2, 2, 30, 156, 3, 2, 2, 2, 32, 158, 3, 2, 2, 2, 34, 160, 
+	3, 2, 2, 2, 36, 162, 3, 2, 2, 2, 38, 164, 3, 2, 2, 2, 40, 166, 3, 2, 2, 
+	2, 42, 48, 5, 4, 3, 2, 43, 44, 7, 25, 2, 2, 44, 45, 5, 2, 2, 2, 45, 46, 
+	7, 26, 2, 2, 46, 47, 5, 2, 2, 2, 47, 49, 3, 2, 2, 2, 48, 43, 3, 2, 2, 2, 
+	48, 49, 3, 2, 2, 2, 49, 3, 3, 2, 2, 2, 50, 55, 5, 6, 4, 2, 51, 52, 7, 19, 
+	2, 2, 52, 54, 5, 6, 4, 2, 53, 51, 3, 2, 2, 2, 54, 57, 3, 2, 2, 2, 55, 53, 
+	3, 2, 2, 2, 55, 56, 3, 2, 2, 2, 56, 5, 3, 2, 2, 2, 57, 55, 3, 2, 2, 2, 
+	58, 63, 5, 8, 5, 2, 59, 60, 7, 21, 2, 2, 60, 62, 5, 8, 5, 2, 61, 59, 3, 
+	2, 2, 2, 62, 65, 3, 2, 2, 2, 63, 61, 3, 2, 2, 2, 63, 64, 3, 2, 2, 2, 64, 
+	7, 3, 2, 2, 2, 65, 63, 3, 2, 2, 2, 66, 71, 5, 10, 6, 2, 67, 68, 7, 20, 
+	2, 2, 68, 70, 5, 10, 6, 2, 69, 67, 3, 2, 2, 2, 70, 73, 3, 2, 2, 2, 71, 
+	69, 3, 2, 2, 2, 71, 72, 3, 2, 2, 2, 72, 9, 3, 2, 2, 2, 73, 71, 3, 2, 2, 
+	2, 74, 79, 5, 12, 7, 2, 75, 76, 9, 2, 2, 2, 76, 78, 5, 12, 7, 2, 77, 75, 
+	3, 2, 2, 2, 78, 81, 3, 2, 2, 2, 79, 77, 3, 2, 2, 2, 79, 80, 3, 2, 2, 2, 
+	80, 11, 3, 2, 2, 2, 81, 79, 3, 2, 2, 2, 82, 87, 5, 14, 8, 2, 83, 84, 9, 
+	3, 2, 2, 84, 86, 5, 14, 8, 2, 85, 83, 3, 2, 2, 2, 86, 89, 3, 2, 2, 2, 87, 
+	85, 3, 2, 2, 2, 87, 88, 3, 2, 2, 2, 88, 13, 3, 2, 2, 2, 89, 87, 3, 2, 2, 
+	2, 90, 95, 5, 16, 9, 2, 91, 92, 9, 4, 2, 2, 92, 94, 5, 16, 9, 2, 93, 91, 
+	3, 2, 2, 2, 94, 97, 3, 2, 2, 2, 95, 93, 3, 2, 2, 2, 95, 96, 3, 2, 2, 2, 
+	96, 15, 3, 2, 2, 2, 97, 95, 3, 2, 2, 2, 98, 103, 5, 18, 10, 2, 99, 100, 
+	9, 5, 2, 2, 100, 102, 5, 18, 10, 2, 101, 99, 3, 2, 2, 2, 102, 105, 3, 2, 
+	2, 2, 103, 101, 3, 2, 2, 2, 103, 104, 3, 2, 2, 2, 104, 17, 3, 2, 2, 2, 
+	105, 103, 3, 2, 2, 2, 106, 111, 5, 20, 11, 2, 107, 108, 7, 24, 2, 2, 108, 
+	110, 5, 20, 11, 2, 109, 107, 3, 2, 2, 2, 110, 113, 3, 2, 2, 2, 111, 109, 
+	3, 2, 2, 2, 111, 112, 3, 2, 2, 2, 112, 19, 3, 2, 2, 2, 113, 111, 3, 2, 
+	2, 2, 114, 115, 9, 6, 2, 2, 115, 131, 5, 20, 11, 2, 116, 120, 5, 40, 21, 
+	2, 117, 119, 5, 22, 12, 2, 118, 117, 3, 2, 2, 2, 119, 122, 3, 2, 2, 2, 
+	120, 118, 3, 2, 2, 2, 120, 121, 3, 2, 2, 2, 121, 131, 3, 2, 2, 2, 122, 
+	120, 3, 2, 2, 2, 123, 127, 5, 26, 14, 2, 124, 126, 5, 22, 12, 2, 125, 124, 
+	3, 2, 2, 2, 126, 129, 3, 2, 2, 2, 127, 125, 3, 2, 2, 2, 127, 128, 3, 2, 
+	2, 2, 128, 131, 3, 2, 2, 2, 129, 127, 3, 2, 2, 2, 130, 114, 3, 2, 2, 2, 
+	130, 116, 3, 2, 2, 2, 130, 123, 3, 2, 2, 2, 131, 21, 3, 2, 2, 2, 132, 133, 
+	7, 23, 2, 2, 133, 139, 5, 24, 13, 2, 134, 135, 7, 5, 2, 2, 135, 136, 5, 
+	2, 2, 2, 136, 137, 7, 6, 2, 2, 137, 139, 3, 2, 2, 2, 138, 132, 3, 2, 2, 
+	2, 138, 134, 3, 2, 2, 2, 139, 23, 3, 2, 2, 2, 140, 141, 9, 7, 2, 2, 141, 
+	25, 3, 2, 2, 2, 142, 153, 5, 30, 16, 2, 143, 153, 5, 38, 20, 2, 144, 153, 
+	5, 36, 19, 2, 145, 146, 7, 3, 2, 2, 146, 147, 5, 2, 2, 2, 147, 148, 7, 
+	4, 2, 2, 148, 153, 3, 2, 2, 2, 149, 153, 5, 28, 15, 2, 150, 153, 5, 32, 
+	17, 2, 151, 153, 5, 34, 18, 2, 152, 142, 3, 2, 2, 2, 152, 143, 3, 2, 2, 
+	2, 152, 144, 3, 2, 2, 2, 152, 145, 3, 2, 2, 2, 152, 149, 3, 2, 2, 2, 152, 
+	150, 3, 2, 2, 2, 152, 151, 3, 2, 2, 2, 153, 27, 3, 2, 2, 2, 154, 155, 7, 
+	34, 2, 2, 155, 29, 3, 2, 2, 2, 156, 157, 7, 36, 2, 2, 157, 31, 3, 2, 2, 
+	2, 158, 159, 9, 8, 2, 2, 159, 33, 3, 2, 2, 2, 160, 161, 7, 32, 2, 2, 161, 
+	35, 3, 2, 2, 2, 162, 163, 9, 9, 2, 2, 163, 37, 3, 2, 2, 2, 164, 165, 7, 
+	33, 2, 2, 165, 39, 3, 2, 2, 2, 166, 167, 7, 33, 2, 2, 167, 168, 7, 3, 2, 
+	2, 168, 173, 5, 2, 2, 2, 169, 170, 7, 22, 2, 2, 170, 172, 5, 2, 2, 2, 171, 
+	169, 3, 2, 2, 2, 172, 175, 3, 2, 2, 2, 173, 171, 3, 2, 2, 2, 173, 174, 
+	3, 2, 2, 2, 174, 176, 3, 2, 2, 2, 175, 173, 3, 2, 2, 2, 176, 177, 7, 4, 
+	2, 2, 177, 41, 3, 2, 2, 2, 17, 48, 55, 63, 71, 79, 87, 95, 103, 111, 120, 
+	127, 130, 138, 152, 173,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
 
 var literalNames = []string{
-	"", "'('", "')'", "'+'", "'-'", "'*'", "'/'", "'%'", "'>'", "'<'", "'=='", 
-	"'!='", "'||'", "'&&'", "'xor'", "','", "'.'", "'^'", "'pi'", "", "'i'", 
-	"", "", "'\"'",
+	"", "'('", "')'", "'['", "']'", "'+'", "'-'", "'*'", "'/'", "'%'", "'>'", 
+	"'<'", "'>='", "'<='", "'=='", "'!='", "'!'", "'||'", "'&&'", "'xor'", 
+	"','", "'.'", "'^'", "'?'", "':'", "'pi'", "", "'i'", "'true'", "'false'", 
+	"'null'", "", "", "'\"'",
 }
 var symbolicNames = []string{
-	"", "LPAREN", "RPAREN", "PLUS", "MINUS", "TIMES", "DIV", "MOD", "GT", "LT", 
-	"EQ", "NOT_EQ", "OR", "AND", "XOR", "COMMA", "POINT", "POW", "PI", "EULER", 
-	"I", "VARIABLE", "QUOTED_STRING", "QUOTE", "SCIENTIFIC_NUMBER", "WS",
+	"", "LPAREN", "RPAREN", "LBRACKET", "RBRACKET", "PLUS", "MINUS", "TIMES", 
+	"DIV", "MOD", "GT", "LT", "GTE", "LTE", "EQ", "NOT_EQ", "NOT", "OR", "AND", 
+	"XOR", "COMMA", "POINT", "POW", "QUESTION", "COLON", "PI", "EULER", "I", 
+	"TRUE", "FALSE", "NULL", "VARIABLE", "QUOTED_STRING", "QUOTE", "SCIENTIFIC_NUMBER", 
+	"WS",
 }
 
 var ruleNames = []string{
-	"expression", "multiplyingExpression", "powExpression", "signedAtom", "binaryOp", 
-	"atom", "str", "scientific", "constant", "variable", "function", "relop",
+	"expression", "orExpression", "xorExpression", "andExpression", "equalityExpression", 
+	"relationalExpression", "additiveExpression", "multiplyingExpression", 
+	"powExpression", "signedAtom", "accessor", "identifier", "atom", "str", 
+	"scientific", "boolean", "null", "constant", "variable", "function",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -105,45 +148,63 @@ const (
 	ExpressionParserEOF = antlr.TokenEOF
 	ExpressionParserLPAREN = 1
 	ExpressionParserRPAREN = 2
-	ExpressionParserPLUS = 3
-	ExpressionParserMINUS = 4
-	ExpressionParserTIMES = 5
-	ExpressionParserDIV = 6
-	ExpressionParserMOD = 7
-	ExpressionParserGT = 8
-	ExpressionParserLT = 9
-	ExpressionParserEQ = 10
-	ExpressionParserNOT_EQ = 11
-	ExpressionParserOR = 12
-	ExpressionParserAND = 13
-	ExpressionParserXOR = 14
-	ExpressionParserCOMMA = 15
-	ExpressionParserPOINT = 16
-	ExpressionParserPOW = 17
-	ExpressionParserPI = 18
-	ExpressionParserEULER = 19
-	ExpressionParserI = 20
-	ExpressionParserVARIABLE = 21
-	ExpressionParserQUOTED_STRING = 22
-	ExpressionParserQUOTE = 23
-	ExpressionParserSCIENTIFIC_NUMBER = 24
-	ExpressionParserWS = 25
+	ExpressionParserLBRACKET = 3
+	ExpressionParserRBRACKET = 4
+	ExpressionParserPLUS = 5
+	ExpressionParserMINUS = 6
+	ExpressionParserTIMES = 7
+	ExpressionParserDIV = 8
+	ExpressionParserMOD = 9
+	ExpressionParserGT = 10
+	ExpressionParserLT = 11
+	ExpressionParserGTE = 12
+	ExpressionParserLTE = 13
+	ExpressionParserEQ = 14
+	ExpressionParserNOT_EQ = 15
+	ExpressionParserNOT = 16
+	ExpressionParserOR = 17
+	ExpressionParserAND = 18
+	ExpressionParserXOR = 19
+	ExpressionParserCOMMA = 20
+	ExpressionParserPOINT = 21
+	ExpressionParserPOW = 22
+	ExpressionParserQUESTION = 23
+	ExpressionParserCOLON = 24
+	ExpressionParserPI = 25
+	ExpressionParserEULER = 26
+	ExpressionParserI = 27
+	ExpressionParserTRUE = 28
+	ExpressionParserFALSE = 29
+	ExpressionParserNULL = 30
+	ExpressionParserVARIABLE = 31
+	ExpressionParserQUOTED_STRING = 32
+	ExpressionParserQUOTE = 33
+	ExpressionParserSCIENTIFIC_NUMBER = 34
+	ExpressionParserWS = 35
 )
 
 // ExpressionParser rules.
 const (
 	ExpressionParserRULE_expression = 0
-	ExpressionParserRULE_multiplyingExpression = 1
-	ExpressionParserRULE_powExpression = 2
-	ExpressionParserRULE_signedAtom = 3
-	ExpressionParserRULE_binaryOp = 4
-	ExpressionParserRULE_atom = 5
-	ExpressionParserRULE_str = 6
-	ExpressionParserRULE_scientific = 7
-	ExpressionParserRULE_constant = 8
-	ExpressionParserRULE_variable = 9
-	ExpressionParserRULE_function = 10
-	ExpressionParserRULE_relop = 11
+	ExpressionParserRULE_orExpression = 1
+	ExpressionParserRULE_xorExpression = 2
+	ExpressionParserRULE_andExpression = 3
+	ExpressionParserRULE_equalityExpression = 4
+	ExpressionParserRULE_relationalExpression = 5
+	ExpressionParserRULE_additiveExpression = 6
+	ExpressionParserRULE_multiplyingExpression = 7
+	ExpressionParserRULE_powExpression = 8
+	ExpressionParserRULE_signedAtom = 9
+	ExpressionParserRULE_accessor = 10
+	ExpressionParserRULE_identifier = 11
+	ExpressionParserRULE_atom = 12
+	ExpressionParserRULE_str = 13
+	ExpressionParserRULE_scientific = 14
+	ExpressionParserRULE_boolean = 15
+	ExpressionParserRULE_null = 16
+	ExpressionParserRULE_constant = 17
+	ExpressionParserRULE_variable = 18
+	ExpressionParserRULE_function = 19
 )
 
 // IExpressionContext is an interface to support dynamic dispatch.
@@ -184,7 +245,945 @@ func NewExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, i
 
 func (s *ExpressionContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ExpressionContext) AllMultiplyingExpression() []IMultiplyingExpressionContext {
+func (s *ExpressionContext) OrExpression() IOrExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IOrExpressionContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IOrExpressionContext)
+}
+
+func (s *ExpressionContext) QUESTION() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserQUESTION, 0)
+}
+
+func (s *ExpressionContext) AllExpression() []IExpressionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IExpressionContext)(nil)).Elem())
+	var tst = make([]IExpressionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IExpressionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *ExpressionContext) Expression(i int) IExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ExpressionContext) COLON() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserCOLON, 0)
+}
+
+func (s *ExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *ExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.EnterExpression(s)
+	}
+}
+
+func (s *ExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.ExitExpression(s)
+	}
+}
+
+
+
+
+func (p *ExpressionParser) Expression() (localctx IExpressionContext) {
+	localctx = NewExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 0, ExpressionParserRULE_expression)
+	var _la int
+
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(40)
+		p.OrExpression()
+	}
+	p.SetState(46)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+
+	if _la == ExpressionParserQUESTION {
+		{
+			p.SetState(41)
+			p.Match(ExpressionParserQUESTION)
+		}
+		{
+			p.SetState(42)
+			p.Expression()
+		}
+		{
+			p.SetState(43)
+			p.Match(ExpressionParserCOLON)
+		}
+		{
+			p.SetState(44)
+			p.Expression()
+		}
+
+	}
+
+
+
+	return localctx
+}
+
+
+// IOrExpressionContext is an interface to support dynamic dispatch.
+type IOrExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsOrExpressionContext differentiates from other interfaces.
+	IsOrExpressionContext()
+}
+
+type OrExpressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyOrExpressionContext() *OrExpressionContext {
+	var p = new(OrExpressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExpressionParserRULE_orExpression
+	return p
+}
+
+func (*OrExpressionContext) IsOrExpressionContext() {}
+
+func NewOrExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *OrExpressionContext {
+	var p = new(OrExpressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExpressionParserRULE_orExpression
+
+	return p
+}
+
+func (s *OrExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *OrExpressionContext) AllXorExpression() []IXorExpressionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IXorExpressionContext)(nil)).Elem())
+	var tst = make([]IXorExpressionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IXorExpressionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *OrExpressionContext) XorExpression(i int) IXorExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IXorExpressionContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IXorExpressionContext)
+}
+
+func (s *OrExpressionContext) AllOR() []antlr.TerminalNode {
+	return s.GetTokens(ExpressionParserOR)
+}
+
+func (s *OrExpressionContext) OR(i int) antlr.TerminalNode {
+	return s.GetToken(ExpressionParserOR, i)
+}
+
+func (s *OrExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *OrExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *OrExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.EnterOrExpression(s)
+	}
+}
+
+func (s *OrExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.ExitOrExpression(s)
+	}
+}
+
+
+
+
+func (p *ExpressionParser) OrExpression() (localctx IOrExpressionContext) {
+	localctx = NewOrExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 2, ExpressionParserRULE_orExpression)
+	var _la int
+
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(48)
+		p.XorExpression()
+	}
+	p.SetState(53)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+
+	for _la == ExpressionParserOR {
+		{
+			p.SetState(49)
+			p.Match(ExpressionParserOR)
+		}
+		{
+			p.SetState(50)
+			p.XorExpression()
+		}
+
+
+		p.SetState(55)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+
+
+	return localctx
+}
+
+
+// IXorExpressionContext is an interface to support dynamic dispatch.
+type IXorExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsXorExpressionContext differentiates from other interfaces.
+	IsXorExpressionContext()
+}
+
+type XorExpressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyXorExpressionContext() *XorExpressionContext {
+	var p = new(XorExpressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExpressionParserRULE_xorExpression
+	return p
+}
+
+func (*XorExpressionContext) IsXorExpressionContext() {}
+
+func NewXorExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *XorExpressionContext {
+	var p = new(XorExpressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExpressionParserRULE_xorExpression
+
+	return p
+}
+
+func (s *XorExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *XorExpressionContext) AllAndExpression() []IAndExpressionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAndExpressionContext)(nil)).Elem())
+	var tst = make([]IAndExpressionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAndExpressionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *XorExpressionContext) AndExpression(i int) IAndExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAndExpressionContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAndExpressionContext)
+}
+
+func (s *XorExpressionContext) AllXOR() []antlr.TerminalNode {
+	return s.GetTokens(ExpressionParserXOR)
+}
+
+func (s *XorExpressionContext) XOR(i int) antlr.TerminalNode {
+	return s.GetToken(ExpressionParserXOR, i)
+}
+
+func (s *XorExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *XorExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *XorExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.EnterXorExpression(s)
+	}
+}
+
+func (s *XorExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.ExitXorExpression(s)
+	}
+}
+
+
+
+
+func (p *ExpressionParser) XorExpression() (localctx IXorExpressionContext) {
+	localctx = NewXorExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 4, ExpressionParserRULE_xorExpression)
+	var _la int
+
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(56)
+		p.AndExpression()
+	}
+	p.SetState(61)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+
+	for _la == ExpressionParserXOR {
+		{
+			p.SetState(57)
+			p.Match(ExpressionParserXOR)
+		}
+		{
+			p.SetState(58)
+			p.AndExpression()
+		}
+
+
+		p.SetState(63)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+
+
+	return localctx
+}
+
+
+// IAndExpressionContext is an interface to support dynamic dispatch.
+type IAndExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsAndExpressionContext differentiates from other interfaces.
+	IsAndExpressionContext()
+}
+
+type AndExpressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyAndExpressionContext() *AndExpressionContext {
+	var p = new(AndExpressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExpressionParserRULE_andExpression
+	return p
+}
+
+func (*AndExpressionContext) IsAndExpressionContext() {}
+
+func NewAndExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AndExpressionContext {
+	var p = new(AndExpressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExpressionParserRULE_andExpression
+
+	return p
+}
+
+func (s *AndExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *AndExpressionContext) AllEqualityExpression() []IEqualityExpressionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IEqualityExpressionContext)(nil)).Elem())
+	var tst = make([]IEqualityExpressionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IEqualityExpressionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *AndExpressionContext) EqualityExpression(i int) IEqualityExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IEqualityExpressionContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IEqualityExpressionContext)
+}
+
+func (s *AndExpressionContext) AllAND() []antlr.TerminalNode {
+	return s.GetTokens(ExpressionParserAND)
+}
+
+func (s *AndExpressionContext) AND(i int) antlr.TerminalNode {
+	return s.GetToken(ExpressionParserAND, i)
+}
+
+func (s *AndExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AndExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *AndExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.EnterAndExpression(s)
+	}
+}
+
+func (s *AndExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.ExitAndExpression(s)
+	}
+}
+
+
+
+
+func (p *ExpressionParser) AndExpression() (localctx IAndExpressionContext) {
+	localctx = NewAndExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, ExpressionParserRULE_andExpression)
+	var _la int
+
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(64)
+		p.EqualityExpression()
+	}
+	p.SetState(69)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+
+	for _la == ExpressionParserAND {
+		{
+			p.SetState(65)
+			p.Match(ExpressionParserAND)
+		}
+		{
+			p.SetState(66)
+			p.EqualityExpression()
+		}
+
+
+		p.SetState(71)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+
+
+	return localctx
+}
+
+
+// IEqualityExpressionContext is an interface to support dynamic dispatch.
+type IEqualityExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsEqualityExpressionContext differentiates from other interfaces.
+	IsEqualityExpressionContext()
+}
+
+type EqualityExpressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyEqualityExpressionContext() *EqualityExpressionContext {
+	var p = new(EqualityExpressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExpressionParserRULE_equalityExpression
+	return p
+}
+
+func (*EqualityExpressionContext) IsEqualityExpressionContext() {}
+
+func NewEqualityExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *EqualityExpressionContext {
+	var p = new(EqualityExpressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExpressionParserRULE_equalityExpression
+
+	return p
+}
+
+func (s *EqualityExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *EqualityExpressionContext) AllRelationalExpression() []IRelationalExpressionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IRelationalExpressionContext)(nil)).Elem())
+	var tst = make([]IRelationalExpressionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IRelationalExpressionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *EqualityExpressionContext) RelationalExpression(i int) IRelationalExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IRelationalExpressionContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IRelationalExpressionContext)
+}
+
+func (s *EqualityExpressionContext) AllEQ() []antlr.TerminalNode {
+	return s.GetTokens(ExpressionParserEQ)
+}
+
+func (s *EqualityExpressionContext) EQ(i int) antlr.TerminalNode {
+	return s.GetToken(ExpressionParserEQ, i)
+}
+
+func (s *EqualityExpressionContext) AllNOT_EQ() []antlr.TerminalNode {
+	return s.GetTokens(ExpressionParserNOT_EQ)
+}
+
+func (s *EqualityExpressionContext) NOT_EQ(i int) antlr.TerminalNode {
+	return s.GetToken(ExpressionParserNOT_EQ, i)
+}
+
+func (s *EqualityExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *EqualityExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *EqualityExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.EnterEqualityExpression(s)
+	}
+}
+
+func (s *EqualityExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.ExitEqualityExpression(s)
+	}
+}
+
+
+
+
+func (p *ExpressionParser) EqualityExpression() (localctx IEqualityExpressionContext) {
+	localctx = NewEqualityExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, ExpressionParserRULE_equalityExpression)
+	var _la int
+
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(72)
+		p.RelationalExpression()
+	}
+	p.SetState(77)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+
+	for _la == ExpressionParserEQ || _la == ExpressionParserNOT_EQ {
+		p.SetState(73)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == ExpressionParserEQ || _la == ExpressionParserNOT_EQ) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+		    p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+		{
+			p.SetState(74)
+			p.RelationalExpression()
+		}
+
+
+		p.SetState(79)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+
+
+	return localctx
+}
+
+
+// IRelationalExpressionContext is an interface to support dynamic dispatch.
+type IRelationalExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsRelationalExpressionContext differentiates from other interfaces.
+	IsRelationalExpressionContext()
+}
+
+type RelationalExpressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyRelationalExpressionContext() *RelationalExpressionContext {
+	var p = new(RelationalExpressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExpressionParserRULE_relationalExpression
+	return p
+}
+
+func (*RelationalExpressionContext) IsRelationalExpressionContext() {}
+
+func NewRelationalExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RelationalExpressionContext {
+	var p = new(RelationalExpressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExpressionParserRULE_relationalExpression
+
+	return p
+}
+
+func (s *RelationalExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *RelationalExpressionContext) AllAdditiveExpression() []IAdditiveExpressionContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAdditiveExpressionContext)(nil)).Elem())
+	var tst = make([]IAdditiveExpressionContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAdditiveExpressionContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *RelationalExpressionContext) AdditiveExpression(i int) IAdditiveExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAdditiveExpressionContext)(nil)).Elem(), i)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAdditiveExpressionContext)
+}
+
+func (s *RelationalExpressionContext) AllGT() []antlr.TerminalNode {
+	return s.GetTokens(ExpressionParserGT)
+}
+
+func (s *RelationalExpressionContext) GT(i int) antlr.TerminalNode {
+	return s.GetToken(ExpressionParserGT, i)
+}
+
+func (s *RelationalExpressionContext) AllLT() []antlr.TerminalNode {
+	return s.GetTokens(ExpressionParserLT)
+}
+
+func (s *RelationalExpressionContext) LT(i int) antlr.TerminalNode {
+	return s.GetToken(ExpressionParserLT, i)
+}
+
+func (s *RelationalExpressionContext) AllGTE() []antlr.TerminalNode {
+	return s.GetTokens(ExpressionParserGTE)
+}
+
+func (s *RelationalExpressionContext) GTE(i int) antlr.TerminalNode {
+	return s.GetToken(ExpressionParserGTE, i)
+}
+
+func (s *RelationalExpressionContext) AllLTE() []antlr.TerminalNode {
+	return s.GetTokens(ExpressionParserLTE)
+}
+
+func (s *RelationalExpressionContext) LTE(i int) antlr.TerminalNode {
+	return s.GetToken(ExpressionParserLTE, i)
+}
+
+func (s *RelationalExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *RelationalExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *RelationalExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.EnterRelationalExpression(s)
+	}
+}
+
+func (s *RelationalExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.ExitRelationalExpression(s)
+	}
+}
+
+
+
+
+func (p *ExpressionParser) RelationalExpression() (localctx IRelationalExpressionContext) {
+	localctx = NewRelationalExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, ExpressionParserRULE_relationalExpression)
+	var _la int
+
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(80)
+		p.AdditiveExpression()
+	}
+	p.SetState(85)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+
+	for (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << ExpressionParserGT) | (1 << ExpressionParserLT) | (1 << ExpressionParserGTE) | (1 << ExpressionParserLTE))) != 0) {
+		p.SetState(81)
+		_la = p.GetTokenStream().LA(1)
+
+		if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << ExpressionParserGT) | (1 << ExpressionParserLT) | (1 << ExpressionParserGTE) | (1 << ExpressionParserLTE))) != 0)) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+		    p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+		{
+			p.SetState(82)
+			p.AdditiveExpression()
+		}
+
+
+		p.SetState(87)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+
+
+	return localctx
+}
+
+
+// IAdditiveExpressionContext is an interface to support dynamic dispatch.
+type IAdditiveExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsAdditiveExpressionContext differentiates from other interfaces.
+	IsAdditiveExpressionContext()
+}
+
+type AdditiveExpressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyAdditiveExpressionContext() *AdditiveExpressionContext {
+	var p = new(AdditiveExpressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExpressionParserRULE_additiveExpression
+	return p
+}
+
+func (*AdditiveExpressionContext) IsAdditiveExpressionContext() {}
+
+func NewAdditiveExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AdditiveExpressionContext {
+	var p = new(AdditiveExpressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExpressionParserRULE_additiveExpression
+
+	return p
+}
+
+func (s *AdditiveExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *AdditiveExpressionContext) AllMultiplyingExpression() []IMultiplyingExpressionContext {
 	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMultiplyingExpressionContext)(nil)).Elem())
 	var tst = make([]IMultiplyingExpressionContext, len(ts))
 
@@ -197,7 +1196,7 @@ func (s *ExpressionContext) AllMultiplyingExpression() []IMultiplyingExpressionC
 	return tst
 }
 
-func (s *ExpressionContext) MultiplyingExpression(i int) IMultiplyingExpressionContext {
+func (s *AdditiveExpressionContext) MultiplyingExpression(i int) IMultiplyingExpressionContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMultiplyingExpressionContext)(nil)).Elem(), i)
 
 	if t == nil {
@@ -207,49 +1206,49 @@ func (s *ExpressionContext) MultiplyingExpression(i int) IMultiplyingExpressionC
 	return t.(IMultiplyingExpressionContext)
 }
 
-func (s *ExpressionContext) AllPLUS() []antlr.TerminalNode {
+func (s *AdditiveExpressionContext) AllPLUS() []antlr.TerminalNode {
 	return s.GetTokens(ExpressionParserPLUS)
 }
 
-func (s *ExpressionContext) PLUS(i int) antlr.TerminalNode {
+func (s *AdditiveExpressionContext) PLUS(i int) antlr.TerminalNode {
 	return s.GetToken(ExpressionParserPLUS, i)
 }
 
-func (s *ExpressionContext) AllMINUS() []antlr.TerminalNode {
+func (s *AdditiveExpressionContext) AllMINUS() []antlr.TerminalNode {
 	return s.GetTokens(ExpressionParserMINUS)
 }
 
-func (s *ExpressionContext) MINUS(i int) antlr.TerminalNode {
+func (s *AdditiveExpressionContext) MINUS(i int) antlr.TerminalNode {
 	return s.GetToken(ExpressionParserMINUS, i)
 }
 
-func (s *ExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *AdditiveExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *AdditiveExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
 
-func (s *ExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *AdditiveExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(ExpressionListener); ok {
-		listenerT.EnterExpression(s)
+		listenerT.EnterAdditiveExpression(s)
 	}
 }
 
-func (s *ExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *AdditiveExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(ExpressionListener); ok {
-		listenerT.ExitExpression(s)
+		listenerT.ExitAdditiveExpression(s)
 	}
 }
 
 
 
 
-func (p *ExpressionParser) Expression() (localctx IExpressionContext) {
-	localctx = NewExpressionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 0, ExpressionParserRULE_expression)
+func (p *ExpressionParser) AdditiveExpression() (localctx IAdditiveExpressionContext) {
+	localctx = NewAdditiveExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, ExpressionParserRULE_additiveExpression)
 	var _la int
 
 
@@ -271,16 +1270,16 @@ func (p *ExpressionParser) Expression() (localctx IExpressionContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(24)
+		p.SetState(88)
 		p.MultiplyingExpression()
 	}
-	p.SetState(29)
+	p.SetState(93)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	for _la == ExpressionParserPLUS || _la == ExpressionParserMINUS {
-		p.SetState(25)
+		p.SetState(89)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == ExpressionParserPLUS || _la == ExpressionParserMINUS) {
@@ -290,12 +1289,12 @@ func (p *ExpressionParser) Expression() (localctx IExpressionContext) {
 			p.Consume()
 		}
 		{
-			p.SetState(26)
+			p.SetState(90)
 			p.MultiplyingExpression()
 		}
 
 
-		p.SetState(31)
+		p.SetState(95)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -417,7 +1416,7 @@ func (s *MultiplyingExpressionContext) ExitRule(listener antlr.ParseTreeListener
 
 func (p *ExpressionParser) MultiplyingExpression() (localctx IMultiplyingExpressionContext) {
 	localctx = NewMultiplyingExpressionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 2, ExpressionParserRULE_multiplyingExpression)
+	p.EnterRule(localctx, 14, ExpressionParserRULE_multiplyingExpression)
 	var _la int
 
 
@@ -439,16 +1438,16 @@ func (p *ExpressionParser) MultiplyingExpression() (localctx IMultiplyingExpress
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(32)
+		p.SetState(96)
 		p.PowExpression()
 	}
-	p.SetState(37)
+	p.SetState(101)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	for (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << ExpressionParserTIMES) | (1 << ExpressionParserDIV) | (1 << ExpressionParserMOD))) != 0) {
-		p.SetState(33)
+		p.SetState(97)
 		_la = p.GetTokenStream().LA(1)
 
 		if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << ExpressionParserTIMES) | (1 << ExpressionParserDIV) | (1 << ExpressionParserMOD))) != 0)) {
@@ -458,12 +1457,12 @@ func (p *ExpressionParser) MultiplyingExpression() (localctx IMultiplyingExpress
 			p.Consume()
 		}
 		{
-			p.SetState(34)
+			p.SetState(98)
 			p.PowExpression()
 		}
 
 
-		p.SetState(39)
+		p.SetState(103)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -569,7 +1568,7 @@ func (s *PowExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *ExpressionParser) PowExpression() (localctx IPowExpressionContext) {
 	localctx = NewPowExpressionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 4, ExpressionParserRULE_powExpression)
+	p.EnterRule(localctx, 16, ExpressionParserRULE_powExpression)
 	var _la int
 
 
@@ -591,26 +1590,26 @@ func (p *ExpressionParser) PowExpression() (localctx IPowExpressionContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(40)
+		p.SetState(104)
 		p.SignedAtom()
 	}
-	p.SetState(45)
+	p.SetState(109)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	for _la == ExpressionParserPOW {
 		{
-			p.SetState(41)
+			p.SetState(105)
 			p.Match(ExpressionParserPOW)
 		}
 		{
-			p.SetState(42)
+			p.SetState(106)
 			p.SignedAtom()
 		}
 
 
-		p.SetState(47)
+		p.SetState(111)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -692,6 +1691,10 @@ func (s *SignedAtomContext) MINUS() antlr.TerminalNode {
 	return s.GetToken(ExpressionParserMINUS, 0)
 }
 
+func (s *SignedAtomContext) NOT() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserNOT, 0)
+}
+
 func (s *SignedAtomContext) Function() IFunctionContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IFunctionContext)(nil)).Elem(), 0)
 
@@ -702,24 +1705,37 @@ func (s *SignedAtomContext) Function() IFunctionContext {
 	return t.(IFunctionContext)
 }
 
-func (s *SignedAtomContext) Atom() IAtomContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
+func (s *SignedAtomContext) AllAccessor() []IAccessorContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAccessorContext)(nil)).Elem())
+	var tst = make([]IAccessorContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IAccessorContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *SignedAtomContext) Accessor(i int) IAccessorContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAccessorContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IAtomContext)
+	return t.(IAccessorContext)
 }
 
-func (s *SignedAtomContext) BinaryOp() IBinaryOpContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IBinaryOpContext)(nil)).Elem(), 0)
+func (s *SignedAtomContext) Atom() IAtomContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IBinaryOpContext)
+	return t.(IAtomContext)
 }
 
 func (s *SignedAtomContext) GetRuleContext() antlr.RuleContext {
@@ -748,7 +1764,7 @@ func (s *SignedAtomContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *ExpressionParser) SignedAtom() (localctx ISignedAtomContext) {
 	localctx = NewSignedAtomContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 6, ExpressionParserRULE_signedAtom)
+	p.EnterRule(localctx, 18, ExpressionParserRULE_signedAtom)
 	var _la int
 
 
@@ -768,12 +1784,12 @@ func (p *ExpressionParser) SignedAtom() (localctx ISignedAtomContext) {
 		}
 	}()
 
-	p.SetState(53)
+	p.SetState(128)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 3, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 11, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
-		p.SetState(48)
+		p.SetState(112)
 
 		var _lt = p.GetTokenStream().LT(1)
 
@@ -781,7 +1797,7 @@ func (p *ExpressionParser) SignedAtom() (localctx ISignedAtomContext) {
 
 		_la = p.GetTokenStream().LA(1)
 
-		if !(_la == ExpressionParserPLUS || _la == ExpressionParserMINUS) {
+		if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << ExpressionParserPLUS) | (1 << ExpressionParserMINUS) | (1 << ExpressionParserNOT))) != 0)) {
 			var _ri = p.GetErrorHandler().RecoverInline(p)
 
 			localctx.(*SignedAtomContext).operator = _ri
@@ -790,7 +1806,7 @@ func (p *ExpressionParser) SignedAtom() (localctx ISignedAtomContext) {
 			p.Consume()
 		}
 		{
-			p.SetState(49)
+			p.SetState(113)
 			p.SignedAtom()
 		}
 
@@ -798,24 +1814,48 @@ func (p *ExpressionParser) SignedAtom() (localctx ISignedAtomContext) {
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(50)
+			p.SetState(114)
 			p.Function()
 		}
+		p.SetState(118)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+
+		for _la == ExpressionParserLBRACKET || _la == ExpressionParserPOINT {
+			{
+				p.SetState(115)
+				p.Accessor()
+			}
+
+
+			p.SetState(120)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
+		}
 
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(51)
+			p.SetState(121)
 			p.Atom()
 		}
+		p.SetState(125)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
 
 
-	case 4:
-		p.EnterOuterAlt(localctx, 4)
-		{
-			p.SetState(52)
-			p.BinaryOp()
+		for _la == ExpressionParserLBRACKET || _la == ExpressionParserPOINT {
+			{
+				p.SetState(122)
+				p.Accessor()
+			}
+
+
+			p.SetState(127)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
 		}
 
 	}
@@ -825,104 +1865,103 @@ func (p *ExpressionParser) SignedAtom() (localctx ISignedAtomContext) {
 }
 
 
-// IBinaryOpContext is an interface to support dynamic dispatch.
-type IBinaryOpContext interface {
+// IAccessorContext is an interface to support dynamic dispatch.
+type IAccessorContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsBinaryOpContext differentiates from other interfaces.
-	IsBinaryOpContext()
+	// IsAccessorContext differentiates from other interfaces.
+	IsAccessorContext()
 }
 
-type BinaryOpContext struct {
+type AccessorContext struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyBinaryOpContext() *BinaryOpContext {
-	var p = new(BinaryOpContext)
+func NewEmptyAccessorContext() *AccessorContext {
+	var p = new(AccessorContext)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = ExpressionParserRULE_binaryOp
+	p.RuleIndex = ExpressionParserRULE_accessor
 	return p
 }
 
-func (*BinaryOpContext) IsBinaryOpContext() {}
+func (*AccessorContext) IsAccessorContext() {}
 
-func NewBinaryOpContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *BinaryOpContext {
-	var p = new(BinaryOpContext)
+func NewAccessorContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AccessorContext {
+	var p = new(AccessorContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = ExpressionParserRULE_binaryOp
+	p.RuleIndex = ExpressionParserRULE_accessor
 
 	return p
 }
 
-func (s *BinaryOpContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *BinaryOpContext) AllAtom() []IAtomContext {
-	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IAtomContext)(nil)).Elem())
-	var tst = make([]IAtomContext, len(ts))
-
-	for i, t := range ts {
-		if t != nil {
-			tst[i] = t.(IAtomContext)
-		}
-	}
+func (s *AccessorContext) GetParser() antlr.Parser { return s.parser }
 
-	return tst
+func (s *AccessorContext) POINT() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserPOINT, 0)
 }
 
-func (s *BinaryOpContext) Atom(i int) IAtomContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IAtomContext)(nil)).Elem(), i)
+func (s *AccessorContext) Identifier() IIdentifierContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentifierContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IAtomContext)
+	return t.(IIdentifierContext)
+}
+
+func (s *AccessorContext) LBRACKET() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserLBRACKET, 0)
 }
 
-func (s *BinaryOpContext) Relop() IRelopContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IRelopContext)(nil)).Elem(), 0)
+func (s *AccessorContext) Expression() IExpressionContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IExpressionContext)(nil)).Elem(), 0)
 
 	if t == nil {
 		return nil
 	}
 
-	return t.(IRelopContext)
+	return t.(IExpressionContext)
+}
+
+func (s *AccessorContext) RBRACKET() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserRBRACKET, 0)
 }
 
-func (s *BinaryOpContext) GetRuleContext() antlr.RuleContext {
+func (s *AccessorContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *BinaryOpContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *AccessorContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
 
-func (s *BinaryOpContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *AccessorContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(ExpressionListener); ok {
-		listenerT.EnterBinaryOp(s)
+		listenerT.EnterAccessor(s)
 	}
 }
 
-func (s *BinaryOpContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *AccessorContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(ExpressionListener); ok {
-		listenerT.ExitBinaryOp(s)
+		listenerT.ExitAccessor(s)
 	}
 }
 
 
 
 
-func (p *ExpressionParser) BinaryOp() (localctx IBinaryOpContext) {
-	localctx = NewBinaryOpContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, ExpressionParserRULE_binaryOp)
+func (p *ExpressionParser) Accessor() (localctx IAccessorContext) {
+	localctx = NewAccessorContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, ExpressionParserRULE_accessor)
 
 	defer func() {
 		p.ExitRule()
@@ -940,18 +1979,169 @@ func (p *ExpressionParser) BinaryOp() (localctx IBinaryOpContext) {
 		}
 	}()
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(55)
-		p.Atom()
+	p.SetState(136)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case ExpressionParserPOINT:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(130)
+			p.Match(ExpressionParserPOINT)
+		}
+		{
+			p.SetState(131)
+			p.Identifier()
+		}
+
+
+	case ExpressionParserLBRACKET:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(132)
+			p.Match(ExpressionParserLBRACKET)
+		}
+		{
+			p.SetState(133)
+			p.Expression()
+		}
+		{
+			p.SetState(134)
+			p.Match(ExpressionParserRBRACKET)
+		}
+
+
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
-	{
-		p.SetState(56)
-		p.Relop()
+
+
+	return localctx
+}
+
+
+// IIdentifierContext is an interface to support dynamic dispatch.
+type IIdentifierContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsIdentifierContext differentiates from other interfaces.
+	IsIdentifierContext()
+}
+
+type IdentifierContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyIdentifierContext() *IdentifierContext {
+	var p = new(IdentifierContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExpressionParserRULE_identifier
+	return p
+}
+
+func (*IdentifierContext) IsIdentifierContext() {}
+
+func NewIdentifierContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *IdentifierContext {
+	var p = new(IdentifierContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExpressionParserRULE_identifier
+
+	return p
+}
+
+func (s *IdentifierContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *IdentifierContext) VARIABLE() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserVARIABLE, 0)
+}
+
+func (s *IdentifierContext) PI() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserPI, 0)
+}
+
+func (s *IdentifierContext) EULER() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserEULER, 0)
+}
+
+func (s *IdentifierContext) I() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserI, 0)
+}
+
+func (s *IdentifierContext) TRUE() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserTRUE, 0)
+}
+
+func (s *IdentifierContext) FALSE() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserFALSE, 0)
+}
+
+func (s *IdentifierContext) NULL() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserNULL, 0)
+}
+
+func (s *IdentifierContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *IdentifierContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *IdentifierContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.EnterIdentifier(s)
 	}
-	{
-		p.SetState(57)
-		p.Atom()
+}
+
+func (s *IdentifierContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.ExitIdentifier(s)
+	}
+}
+
+
+
+
+func (p *ExpressionParser) Identifier() (localctx IIdentifierContext) {
+	localctx = NewIdentifierContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 22, ExpressionParserRULE_identifier)
+	var _la int
+
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(138)
+	_la = p.GetTokenStream().LA(1)
+
+	if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << ExpressionParserPI) | (1 << ExpressionParserEULER) | (1 << ExpressionParserI) | (1 << ExpressionParserTRUE) | (1 << ExpressionParserFALSE) | (1 << ExpressionParserNULL) | (1 << ExpressionParserVARIABLE))) != 0)) {
+		p.GetErrorHandler().RecoverInline(p)
+	} else {
+	    p.GetErrorHandler().ReportMatch(p)
+		p.Consume()
 	}
 
 
@@ -1056,6 +2246,26 @@ func (s *AtomContext) Str() IStrContext {
 	return t.(IStrContext)
 }
 
+func (s *AtomContext) Boolean() IBooleanContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IBooleanContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IBooleanContext)
+}
+
+func (s *AtomContext) Null() INullContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*INullContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(INullContext)
+}
+
 func (s *AtomContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -1082,7 +2292,7 @@ func (s *AtomContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *ExpressionParser) Atom() (localctx IAtomContext) {
 	localctx = NewAtomContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, ExpressionParserRULE_atom)
+	p.EnterRule(localctx, 24, ExpressionParserRULE_atom)
 
 	defer func() {
 		p.ExitRule()
@@ -1100,14 +2310,14 @@ func (p *ExpressionParser) Atom() (localctx IAtomContext) {
 		}
 	}()
 
-	p.SetState(67)
+	p.SetState(150)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case ExpressionParserSCIENTIFIC_NUMBER:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(59)
+			p.SetState(140)
 			p.Scientific()
 		}
 
@@ -1115,7 +2325,7 @@ func (p *ExpressionParser) Atom() (localctx IAtomContext) {
 	case ExpressionParserVARIABLE:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(60)
+			p.SetState(141)
 			p.Variable()
 		}
 
@@ -1123,7 +2333,7 @@ func (p *ExpressionParser) Atom() (localctx IAtomContext) {
 	case ExpressionParserPI, ExpressionParserEULER, ExpressionParserI:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(61)
+			p.SetState(142)
 			p.Constant()
 		}
 
@@ -1131,15 +2341,15 @@ func (p *ExpressionParser) Atom() (localctx IAtomContext) {
 	case ExpressionParserLPAREN:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(62)
+			p.SetState(143)
 			p.Match(ExpressionParserLPAREN)
 		}
 		{
-			p.SetState(63)
+			p.SetState(144)
 			p.Expression()
 		}
 		{
-			p.SetState(64)
+			p.SetState(145)
 			p.Match(ExpressionParserRPAREN)
 		}
 
@@ -1147,11 +2357,27 @@ func (p *ExpressionParser) Atom() (localctx IAtomContext) {
 	case ExpressionParserQUOTED_STRING:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(66)
+			p.SetState(147)
 			p.Str()
 		}
 
 
+	case ExpressionParserTRUE, ExpressionParserFALSE:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(148)
+			p.Boolean()
+		}
+
+
+	case ExpressionParserNULL:
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(149)
+			p.Null()
+		}
+
+
 
 	default:
 		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
@@ -1230,7 +2456,7 @@ func (s *StrContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *ExpressionParser) Str() (localctx IStrContext) {
 	localctx = NewStrContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, ExpressionParserRULE_str)
+	p.EnterRule(localctx, 26, ExpressionParserRULE_str)
 
 	defer func() {
 		p.ExitRule()
@@ -1250,7 +2476,7 @@ func (p *ExpressionParser) Str() (localctx IStrContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(69)
+		p.SetState(152)
 		p.Match(ExpressionParserQUOTED_STRING)
 	}
 
@@ -1328,7 +2554,7 @@ func (s *ScientificContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *ExpressionParser) Scientific() (localctx IScientificContext) {
 	localctx = NewScientificContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, ExpressionParserRULE_scientific)
+	p.EnterRule(localctx, 28, ExpressionParserRULE_scientific)
 
 	defer func() {
 		p.ExitRule()
@@ -1348,7 +2574,7 @@ func (p *ExpressionParser) Scientific() (localctx IScientificContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(71)
+		p.SetState(154)
 		p.Match(ExpressionParserSCIENTIFIC_NUMBER)
 	}
 
@@ -1358,6 +2584,213 @@ func (p *ExpressionParser) Scientific() (localctx IScientificContext) {
 }
 
 
+// IBooleanContext is an interface to support dynamic dispatch.
+type IBooleanContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsBooleanContext differentiates from other interfaces.
+	IsBooleanContext()
+}
+
+type BooleanContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyBooleanContext() *BooleanContext {
+	var p = new(BooleanContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExpressionParserRULE_boolean
+	return p
+}
+
+func (*BooleanContext) IsBooleanContext() {}
+
+func NewBooleanContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *BooleanContext {
+	var p = new(BooleanContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExpressionParserRULE_boolean
+
+	return p
+}
+
+func (s *BooleanContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *BooleanContext) TRUE() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserTRUE, 0)
+}
+
+func (s *BooleanContext) FALSE() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserFALSE, 0)
+}
+
+func (s *BooleanContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *BooleanContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *BooleanContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.EnterBoolean(s)
+	}
+}
+
+func (s *BooleanContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.ExitBoolean(s)
+	}
+}
+
+
+
+
+func (p *ExpressionParser) Boolean() (localctx IBooleanContext) {
+	localctx = NewBooleanContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 30, ExpressionParserRULE_boolean)
+	var _la int
+
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(156)
+	_la = p.GetTokenStream().LA(1)
+
+	if !(_la == ExpressionParserTRUE || _la == ExpressionParserFALSE) {
+		p.GetErrorHandler().RecoverInline(p)
+	} else {
+	    p.GetErrorHandler().ReportMatch(p)
+		p.Consume()
+	}
+
+
+
+	return localctx
+}
+
+
+// INullContext is an interface to support dynamic dispatch.
+type INullContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsNullContext differentiates from other interfaces.
+	IsNullContext()
+}
+
+type NullContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyNullContext() *NullContext {
+	var p = new(NullContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ExpressionParserRULE_null
+	return p
+}
+
+func (*NullContext) IsNullContext() {}
+
+func NewNullContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *NullContext {
+	var p = new(NullContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ExpressionParserRULE_null
+
+	return p
+}
+
+func (s *NullContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *NullContext) NULL() antlr.TerminalNode {
+	return s.GetToken(ExpressionParserNULL, 0)
+}
+
+func (s *NullContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *NullContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *NullContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.EnterNull(s)
+	}
+}
+
+func (s *NullContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ExpressionListener); ok {
+		listenerT.ExitNull(s)
+	}
+}
+
+
+
+
+func (p *ExpressionParser) Null() (localctx INullContext) {
+	localctx = NewNullContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 32, ExpressionParserRULE_null)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(158)
+		p.Match(ExpressionParserNULL)
+	}
+
+
+
+	return localctx
+}
+
+
 // IConstantContext is an interface to support dynamic dispatch.
 type IConstantContext interface {
 	antlr.ParserRuleContext
@@ -1434,7 +2867,7 @@ func (s *ConstantContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *ExpressionParser) Constant() (localctx IConstantContext) {
 	localctx = NewConstantContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, ExpressionParserRULE_constant)
+	p.EnterRule(localctx, 34, ExpressionParserRULE_constant)
 	var _la int
 
 
@@ -1455,7 +2888,7 @@ func (p *ExpressionParser) Constant() (localctx IConstantContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(73)
+	p.SetState(160)
 	_la = p.GetTokenStream().LA(1)
 
 	if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << ExpressionParserPI) | (1 << ExpressionParserEULER) | (1 << ExpressionParserI))) != 0)) {
@@ -1539,7 +2972,7 @@ func (s *VariableContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *ExpressionParser) Variable() (localctx IVariableContext) {
 	localctx = NewVariableContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, ExpressionParserRULE_variable)
+	p.EnterRule(localctx, 36, ExpressionParserRULE_variable)
 
 	defer func() {
 		p.ExitRule()
@@ -1559,7 +2992,7 @@ func (p *ExpressionParser) Variable() (localctx IVariableContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(75)
+		p.SetState(162)
 		p.Match(ExpressionParserVARIABLE)
 	}
 
@@ -1691,7 +3124,7 @@ func (s *FunctionContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *ExpressionParser) Function() (localctx IFunctionContext) {
 	localctx = NewFunctionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, ExpressionParserRULE_function)
+	p.EnterRule(localctx, 38, ExpressionParserRULE_function)
 	var _la int
 
 
@@ -1713,42 +3146,42 @@ func (p *ExpressionParser) Function() (localctx IFunctionContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(77)
+		p.SetState(164)
 
 		var _m = p.Match(ExpressionParserVARIABLE)
 
 		localctx.(*FunctionContext).fname = _m
 	}
 	{
-		p.SetState(78)
+		p.SetState(165)
 		p.Match(ExpressionParserLPAREN)
 	}
 	{
-		p.SetState(79)
+		p.SetState(166)
 		p.Expression()
 	}
-	p.SetState(84)
+	p.SetState(171)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	for _la == ExpressionParserCOMMA {
 		{
-			p.SetState(80)
+			p.SetState(167)
 			p.Match(ExpressionParserCOMMA)
 		}
 		{
-			p.SetState(81)
+			p.SetState(168)
 			p.Expression()
 		}
 
 
-		p.SetState(86)
+		p.SetState(173)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(87)
+		p.SetState(174)
 		p.Match(ExpressionParserRPAREN)
 	}
 
@@ -1758,132 +3191,3 @@ func (p *ExpressionParser) Function() (localctx IFunctionContext) {
 }
 
 
-// IRelopContext is an interface to support dynamic dispatch.
-type IRelopContext interface {
-	antlr.ParserRuleContext
-
-	// GetParser returns the parser.
-	GetParser() antlr.Parser
-
-	// IsRelopContext differentiates from other interfaces.
-	IsRelopContext()
-}
-
-type RelopContext struct {
-	*antlr.BaseParserRuleContext
-	parser antlr.Parser
-}
-
-func NewEmptyRelopContext() *RelopContext {
-	var p = new(RelopContext)
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = ExpressionParserRULE_relop
-	return p
-}
-
-func (*RelopContext) IsRelopContext() {}
-
-func NewRelopContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *RelopContext {
-	var p = new(RelopContext)
-
-	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
-
-	p.parser = parser
-	p.RuleIndex = ExpressionParserRULE_relop
-
-	return p
-}
-
-func (s *RelopContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *RelopContext) EQ() antlr.TerminalNode {
-	return s.GetToken(ExpressionParserEQ, 0)
-}
-
-func (s *RelopContext) NOT_EQ() antlr.TerminalNode {
-	return s.GetToken(ExpressionParserNOT_EQ, 0)
-}
-
-func (s *RelopContext) GT() antlr.TerminalNode {
-	return s.GetToken(ExpressionParserGT, 0)
-}
-
-func (s *RelopContext) LT() antlr.TerminalNode {
-	return s.GetToken(ExpressionParserLT, 0)
-}
-
-func (s *RelopContext) OR() antlr.TerminalNode {
-	return s.GetToken(ExpressionParserOR, 0)
-}
-
-func (s *RelopContext) AND() antlr.TerminalNode {
-	return s.GetToken(ExpressionParserAND, 0)
-}
-
-func (s *RelopContext) XOR() antlr.TerminalNode {
-	return s.GetToken(ExpressionParserXOR, 0)
-}
-
-func (s *RelopContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *RelopContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
-
-
-func (s *RelopContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(ExpressionListener); ok {
-		listenerT.EnterRelop(s)
-	}
-}
-
-func (s *RelopContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(ExpressionListener); ok {
-		listenerT.ExitRelop(s)
-	}
-}
-
-
-
-
-func (p *ExpressionParser) Relop() (localctx IRelopContext) {
-	localctx = NewRelopContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, ExpressionParserRULE_relop)
-	var _la int
-
-
-	defer func() {
-		p.ExitRule()
-	}()
-
-	defer func() {
-		if err := recover(); err != nil {
-			if v, ok := err.(antlr.RecognitionException); ok {
-				localctx.SetException(v)
-				p.GetErrorHandler().ReportError(p, v)
-				p.GetErrorHandler().Recover(p, v)
-			} else {
-				panic(err)
-			}
-		}
-	}()
-
-	p.EnterOuterAlt(localctx, 1)
-	p.SetState(89)
-	_la = p.GetTokenStream().LA(1)
-
-	if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << ExpressionParserGT) | (1 << ExpressionParserLT) | (1 << ExpressionParserEQ) | (1 << ExpressionParserNOT_EQ) | (1 << ExpressionParserOR) | (1 << ExpressionParserAND) | (1 << ExpressionParserXOR))) != 0)) {
-		p.GetErrorHandler().RecoverInline(p)
-	} else {
-	    p.GetErrorHandler().ReportMatch(p)
-		p.Consume()
-	}
-
-
-
-	return localctx
-}
-
-
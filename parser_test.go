@@ -1,14 +1,36 @@
 package expressions_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"github.com/jamillosantos/go-expressions"
 	. "github.com/franela/goblin"
 	. "github.com/onsi/gomega"
 	"math"
+	"sync"
 	"time"
 )
 
+type address struct {
+	Zip string `json:"zip"`
+}
+
+type customer struct {
+	Name    string
+	Address *address `json:"address"`
+}
+
+type item struct {
+	Price float64 `json:"price,omitempty"`
+}
+
+type order struct {
+	Customer customer `json:"customer"`
+	Items    []item   `json:"items"`
+	secret   string
+}
+
 func TestCompile(t *testing.T) {
 	g := Goblin(t)
 
@@ -25,7 +47,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(5)))
+			Expect(v).To(Equal(int64(5)))
 		})
 
 		g.It("should resolve a simple sequence of additions", func() {
@@ -34,7 +56,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(20)))
+			Expect(v).To(Equal(int64(20)))
 		})
 
 		g.It("should resolve a simple sequence of additions with brackets", func() {
@@ -43,7 +65,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(12)))
+			Expect(v).To(Equal(int64(12)))
 		})
 
 		g.It("should resolve a signed integers (with brackets)", func() {
@@ -52,7 +74,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(-2)))
+			Expect(v).To(Equal(int64(-2)))
 		})
 
 		g.It("should resolve a signed integers (without brackets)", func() {
@@ -61,7 +83,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(2)))
+			Expect(v).To(Equal(int64(2)))
 		})
 
 		g.It("should resolve a multiplication (without brackets)", func() {
@@ -70,7 +92,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(38)))
+			Expect(v).To(Equal(int64(38)))
 		})
 
 		g.It("should resolve a multiplication (with brackets)", func() {
@@ -79,7 +101,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(76)))
+			Expect(v).To(Equal(int64(76)))
 		})
 
 		g.It("should resolve a division (without brackets)", func() {
@@ -88,7 +110,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(6)))
+			Expect(v).To(Equal(int64(6)))
 		})
 
 		g.It("should resolve a division (with brackets)", func() {
@@ -97,7 +119,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(11)))
+			Expect(v).To(Equal(int64(11)))
 		})
 
 		g.It("should resolve a exponentiation", func() {
@@ -106,7 +128,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(8)))
+			Expect(v).To(Equal(int64(8)))
 		})
 
 		g.It("should resolve a modulus", func() {
@@ -115,7 +137,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(2)))
+			Expect(v).To(Equal(int64(2)))
 		})
 
 		g.It("should resolve a modulus (with brackets)", func() {
@@ -126,7 +148,7 @@ func TestCompile(t *testing.T) {
 			Expect(expr).NotTo(BeNil())
 			v, err := expr.Solve(expressions.NewContext(nil, nil))
 			Expect(err).To(BeNil())
-			Expect(v).To(Equal(float64(1)))
+			Expect(v).To(Equal(int64(1)))
 		})
 
 		g.It("should resolve a equation with variables (without brackets)", func() {
@@ -159,6 +181,802 @@ func TestCompile(t *testing.T) {
 			Expect(v).To(Equal(float64(19)))
 		})
 
+		g.It("should solve the same expression and context concurrently", func() {
+			resolver := expressions.NewMapResolver(map[string]interface{}{
+				"x": 4.5,
+				"y": 2,
+			})
+			expr, err := expressions.Compile("(5+x)*y - (y*(x+1))^2 / (2-(x-y))")
+			Expect(err).To(BeNil())
+			ctx := expressions.NewContext(resolver, &expressions.DefaultFunctions{})
+			expected, err := expr.Solve(ctx)
+			Expect(err).To(BeNil())
+
+			var wg sync.WaitGroup
+			results := make(chan interface{}, 64*100)
+			for i := 0; i < 64; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 100; j++ {
+						v, err := expr.Solve(ctx)
+						if err != nil {
+							results <- err
+							continue
+						}
+						results <- v
+					}
+				}()
+			}
+			wg.Wait()
+			close(results)
+			for v := range results {
+				Expect(v).To(Equal(expected))
+			}
+		})
+
+		g.Describe("Numeric types", func() {
+			g.It("should keep integer arithmetic as integers", func() {
+				expr, err := expressions.Compile("2 + 3")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(int64(5)))
+			})
+
+			g.It("should promote to float when a float is involved", func() {
+				expr, err := expressions.Compile("2 + 3.0")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(float64(5)))
+			})
+
+			g.It("should return a float for inexact integer divisions", func() {
+				expr, err := expressions.Compile("7 / 2")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(3.5))
+			})
+
+			g.It("should fail dividing integers by zero", func() {
+				expr, err := expressions.Compile("7 % (2 - 2)")
+				Expect(err).To(BeNil())
+				_, err = expr.Solve(expressions.NewContext(nil, nil))
+				Expect(errors.Is(err, expressions.ErrDivisionByZero)).To(BeTrue())
+			})
+
+			g.It("should return a float for negative exponents", func() {
+				expr, err := expressions.Compile("2 ^ -1")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(0.5))
+			})
+
+			g.It("should keep the precision of large int64 values", func() {
+				resolver := expressions.NewMapResolver(map[string]interface{}{
+					"id": int64(9007199254740993),
+				})
+				expr, err := expressions.Compile("id + 1")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(int64(9007199254740994)))
+			})
+
+			g.It("should use uint64 for values above the int64 range", func() {
+				expr, err := expressions.Compile("9223372036854775807 + 1")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(uint64(9223372036854775808)))
+			})
+
+			g.It("should resolve the lowest int64", func() {
+				expr, err := expressions.Compile("-9223372036854775808")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(int64(math.MinInt64)))
+			})
+
+			g.It("should detect overflows", func() {
+				for _, e := range []string{"18446744073709551615 + 1", "-9223372036854775808 - 1", "4294967296 * 4294967296", "2 ^ 64"} {
+					expr, err := expressions.Compile(e)
+					Expect(err).To(BeNil())
+					_, err = expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).NotTo(BeNil())
+					Expect(fmt.Sprint(err)).To(ContainSubstring("overflows"))
+				}
+			})
+
+			g.It("should accept all Go numeric kinds from the resolver", func() {
+				resolver := expressions.NewMapResolver(map[string]interface{}{
+					"a": int8(1),
+					"b": int16(2),
+					"c": int32(3),
+					"d": uint8(4),
+					"e": uint16(5),
+					"f": uint32(6),
+					"g": uint(7),
+					"h": uint64(8),
+					"j": float32(0.5),
+				})
+				expr, err := expressions.NewCompiler().RemoveConstant("e").Compile("a + b + c + d + e + f + g + h")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(int64(36)))
+				expr, err = expressions.Compile("j * 2 > a")
+				Expect(err).To(BeNil())
+				v, err = expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(false))
+			})
+
+			g.It("should compare uint64 and negative values", func() {
+				expr, err := expressions.Compile("18446744073709551615 > -1")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+		})
+
+		g.Describe("Member access", func() {
+			resolver := expressions.NewMapResolver(map[string]interface{}{
+				"order": &order{
+					Customer: customer{
+						Name: "John Doe",
+						Address: &address{
+							Zip: "12345",
+						},
+					},
+					Items: []item{
+						{Price: 10},
+						{Price: 2.5},
+					},
+					secret: "secret",
+				},
+				"m": map[string]interface{}{
+					"list": []interface{}{1, map[string]int{"e": 3}},
+				},
+				"ids": map[int]string{
+					7: "seven",
+				},
+			})
+
+			g.It("should resolve dotted members using json tags", func() {
+				expr, err := expressions.Compile("order.customer.address.zip")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal("12345"))
+			})
+
+			g.It("should resolve members using field names", func() {
+				expr, err := expressions.Compile("order.customer.Name")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal("John Doe"))
+			})
+
+			g.It("should index slices", func() {
+				expr, err := expressions.Compile("order.items[0].price + order.items[1].price")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(12.5))
+			})
+
+			g.It("should index maps and slices with expressions", func() {
+				expr, err := expressions.Compile("m[\"list\"][2 - 1].e")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(3))
+			})
+
+			g.It("should index maps with integer keys", func() {
+				expr, err := expressions.Compile("ids[7]")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal("seven"))
+			})
+
+			g.It("should index strings by character", func() {
+				expr, err := expressions.Compile("\"ação\"[1]")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal("ç"))
+			})
+
+			g.It("should fail when a member is missing", func() {
+				expr, err := expressions.Compile("order.customer.phone")
+				Expect(err).To(BeNil())
+				_, err = expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).NotTo(BeNil())
+				var memberErr *expressions.MemberError
+				Expect(errors.As(err, &memberErr)).To(BeTrue())
+				Expect(memberErr.Path()).To(Equal("order.customer"))
+				Expect(fmt.Sprint(err)).To(ContainSubstring("'phone'"))
+			})
+
+			g.It("should not expose unexported fields", func() {
+				expr, err := expressions.Compile("order.secret")
+				Expect(err).To(BeNil())
+				_, err = expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).NotTo(BeNil())
+			})
+
+			g.It("should fail when an index is out of range", func() {
+				expr, err := expressions.Compile("order.items[2].price")
+				Expect(err).To(BeNil())
+				_, err = expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(ContainSubstring("order.items"))
+				Expect(fmt.Sprint(err)).To(ContainSubstring("out of range"))
+			})
+
+			g.It("should fail accessing members of nil values", func() {
+				r := expressions.NewMapResolver(map[string]interface{}{
+					"order": &order{},
+				})
+				expr, err := expressions.Compile("order.customer.address.zip")
+				Expect(err).To(BeNil())
+				_, err = expr.Solve(expressions.NewContext(r, nil))
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(ContainSubstring("order.customer.address"))
+				Expect(fmt.Sprint(err)).To(ContainSubstring("nil"))
+			})
+		})
+
+		g.Describe("Runtime errors", func() {
+			resolver := expressions.NewMapResolver(map[string]interface{}{
+				"a":    int64(1),
+				"name": "john",
+				"list": []int{1, 2, 3},
+			})
+
+			g.It("should point to the failing operation", func() {
+				expr, err := expressions.Compile("a * 2 > 1 && (a + name) > 3")
+				Expect(err).To(BeNil())
+				_, err = expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).NotTo(BeNil())
+				var runtimeErr *expressions.RuntimeError
+				Expect(errors.As(err, &runtimeErr)).To(BeTrue())
+				Expect(runtimeErr.Kind).To(Equal("arithmetic"))
+				Expect(runtimeErr.Span.Text()).To(Equal("a + name"))
+				Expect(runtimeErr.Span.Start).To(Equal(14))
+				Expect(runtimeErr.Span.End).To(Equal(22))
+				Expect(runtimeErr.Span.Line).To(Equal(1))
+				Expect(runtimeErr.Span.Column).To(Equal(14))
+				Expect(runtimeErr.Operands).To(Equal([]interface{}{int64(1), "john"}))
+				var wrongType *expressions.WrongTypeError
+				Expect(errors.As(err, &wrongType)).To(BeTrue())
+				Expect(fmt.Sprint(err)).To(Equal("john has not a valid type. (arithmetic `a + name` at 1:14)"))
+			})
+
+			g.It("should point to the failing comparison of a chain", func() {
+				expr, err := expressions.Compile("1 < 2 == name > 3")
+				Expect(err).To(BeNil())
+				_, err = expr.Solve(expressions.NewContext(resolver, nil))
+				var runtimeErr *expressions.RuntimeError
+				Expect(errors.As(err, &runtimeErr)).To(BeTrue())
+				Expect(runtimeErr.Kind).To(Equal("comparison"))
+				Expect(runtimeErr.Span.Text()).To(Equal("name > 3"))
+				Expect(runtimeErr.Operands).To(Equal([]interface{}{"john", int64(3)}))
+			})
+
+			g.It("should point to the failing member or index", func() {
+				expr, err := expressions.Compile("a +\n  list[1 + 2]")
+				Expect(err).To(BeNil())
+				_, err = expr.Solve(expressions.NewContext(resolver, nil))
+				var runtimeErr *expressions.RuntimeError
+				Expect(errors.As(err, &runtimeErr)).To(BeTrue())
+				Expect(runtimeErr.Kind).To(Equal("index"))
+				Expect(runtimeErr.Span.Text()).To(Equal("list[1 + 2]"))
+				Expect(runtimeErr.Span.Line).To(Equal(2))
+				Expect(runtimeErr.Span.Column).To(Equal(2))
+				Expect(runtimeErr.Operands[1]).To(Equal(int64(3)))
+				var memberErr *expressions.MemberError
+				Expect(errors.As(err, &memberErr)).To(BeTrue())
+			})
+
+			g.It("should point to fields and functions", func() {
+				expr, err := expressions.Compile("1 + missing")
+				Expect(err).To(BeNil())
+				_, err = expr.Solve(expressions.NewContext(resolver, nil))
+				var runtimeErr *expressions.RuntimeError
+				Expect(errors.As(err, &runtimeErr)).To(BeTrue())
+				Expect(runtimeErr.Kind).To(Equal("field"))
+				Expect(runtimeErr.Span.Text()).To(Equal("missing"))
+
+				expr, err = expressions.Compile("2 * sqrt(1, 2, 3)")
+				Expect(err).To(BeNil())
+				_, err = expr.Solve(expressions.NewContext(resolver, &expressions.DefaultFunctions{}))
+				Expect(errors.As(err, &runtimeErr)).To(BeTrue())
+				Expect(runtimeErr.Kind).To(Equal("function"))
+				Expect(runtimeErr.Span.Text()).To(Equal("sqrt(1, 2, 3)"))
+				var paramErr *expressions.ParameterError
+				Expect(errors.As(err, &paramErr)).To(BeTrue())
+			})
+
+			g.It("should keep the innermost location", func() {
+				expr, err := expressions.Compile("sqrt(a / (a - 1))")
+				Expect(err).To(BeNil())
+				_, err = expr.Solve(expressions.NewContext(resolver, &expressions.DefaultFunctions{}))
+				var runtimeErr *expressions.RuntimeError
+				Expect(errors.As(err, &runtimeErr)).To(BeTrue())
+				Expect(runtimeErr.Span.Text()).To(Equal("a / (a - 1)"))
+				Expect(errors.Is(err, expressions.ErrDivisionByZero)).To(BeTrue())
+			})
+
+			g.It("should not wrap errors of expressions built by hand", func() {
+				expr := expressions.NewExpressionField("missing")
+				_, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(fmt.Sprint(err)).To(Equal("Value of missing was not found."))
+			})
+		})
+
+		g.Describe("Syntax errors", func() {
+			g.It("should report all syntax errors", func() {
+				expr, err := expressions.Compile("1 + * 2 + (3 - )")
+				Expect(expr).To(BeNil())
+				Expect(err).To(BeAssignableToTypeOf(&expressions.CompileError{}))
+				compileErr := err.(*expressions.CompileError)
+				Expect(compileErr.Source).To(Equal("1 + * 2 + (3 - )"))
+				Expect(compileErr.Errors).To(HaveLen(2))
+				Expect(compileErr.Errors[0].Line).To(Equal(1))
+				Expect(compileErr.Errors[0].Column).To(Equal(4))
+				Expect(compileErr.Errors[0].Offending).To(Equal("*"))
+				Expect(compileErr.Errors[0].Expected).To(ContainElement("VARIABLE"))
+				Expect(compileErr.Errors[1].Column).To(Equal(15))
+				Expect(compileErr.Errors[1].Offending).To(Equal(")"))
+				Expect(fmt.Sprint(err)).To(ContainSubstring("at 1:4"))
+				Expect(fmt.Sprint(err)).To(ContainSubstring("at 1:15"))
+			})
+
+			g.It("should annotate the source with carets", func() {
+				_, err := expressions.Compile("x +\n\t* 3")
+				compileErr := err.(*expressions.CompileError)
+				Expect(compileErr.Errors).To(HaveLen(1))
+				Expect(compileErr.Errors[0].Line).To(Equal(2))
+				Expect(compileErr.Errors[0].Snippet).To(Equal("\t* 3\n\t^"))
+			})
+
+			g.It("should report missing tokens at the end of the input", func() {
+				_, err := expressions.Compile("(1 + 2")
+				compileErr := err.(*expressions.CompileError)
+				Expect(compileErr.Errors).To(HaveLen(1))
+				Expect(compileErr.Errors[0].Offending).To(Equal("<EOF>"))
+				Expect(compileErr.Errors[0].Expected).To(Equal([]string{"')'"}))
+				Expect(compileErr.Errors[0].Snippet).To(Equal("(1 + 2\n      ^"))
+			})
+
+			g.It("should report unexpected trailing input", func() {
+				_, err := expressions.Compile("1 + 2) * 3")
+				Expect(err).NotTo(BeNil())
+				compileErr := err.(*expressions.CompileError)
+				Expect(compileErr.Errors).To(HaveLen(1))
+				Expect(compileErr.Errors[0].Offending).To(Equal(")"))
+				Expect(compileErr.Errors[0].Expected).To(Equal([]string{"<EOF>"}))
+			})
+
+			g.It("should report invalid characters", func() {
+				_, err := expressions.Compile("1 # 2")
+				Expect(err).NotTo(BeNil())
+				compileErr := err.(*expressions.CompileError)
+				Expect(compileErr.Errors[0].Message).To(ContainSubstring("token recognition error"))
+				Expect(compileErr.Errors[0].Column).To(Equal(2))
+				Expect(compileErr.Errors[0].Snippet).To(Equal("1 # 2\n  ^"))
+			})
+		})
+
+		g.Describe("Constants", func() {
+			g.It("should resolve pi", func() {
+				expr, err := expressions.Compile("pi")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(math.Pi))
+			})
+
+			g.It("should resolve e", func() {
+				expr, err := expressions.Compile("2*e")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(2 * math.E))
+			})
+
+			g.It("should resolve i", func() {
+				for s, expected := range map[string]interface{}{
+					"i":             complex(0, 1),
+					"2*i + 1":       complex(1, 2),
+					"(1 + i) * i":   complex(-1, 1),
+					"-i / 2":        complex(0, -0.5),
+					"i ^ 2 == -1":   true,
+					"i * i + 1 != 0": false,
+				} {
+					expr, err := expressions.Compile(s)
+					Expect(err).To(BeNil(), s)
+					v, err := expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).To(BeNil(), s)
+					Expect(v).To(Equal(expected), s)
+				}
+				for _, s := range []string{"i > 0", "i % 2", "i + \"x\""} {
+					expr, err := expressions.Compile(s)
+					Expect(err).To(BeNil(), s)
+					_, err = expr.Solve(expressions.NewContext(nil, nil))
+					Expect(err).NotTo(BeNil(), s)
+				}
+			})
+
+			g.It("should resolve a custom constant without the resolver", func() {
+				compiler := expressions.NewCompiler().SetConstant("g", 9.8)
+				expr, err := compiler.Compile("g*2")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(19.6))
+			})
+
+			g.It("should not leak custom constants to other compilers", func() {
+				expressions.NewCompiler().SetConstant("c", 299792458.0)
+				resolver := expressions.NewMapResolver(map[string]interface{}{
+					"c": 1.0,
+				})
+				expr, err := expressions.Compile("c")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(1.0))
+			})
+
+			g.It("should fallback to the resolver when a constant is removed", func() {
+				resolver := expressions.NewMapResolver(map[string]interface{}{
+					"pi": 3,
+				})
+				expr, err := expressions.NewCompiler().RemoveConstant("pi").Compile("pi")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(3))
+			})
+		})
+
+		g.Describe("Literals", func() {
+			g.It("should resolve true and false", func() {
+				expr, err := expressions.Compile("true")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+				expr, err = expressions.Compile("false")
+				Expect(err).To(BeNil())
+				v, err = expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(false))
+			})
+
+			g.It("should resolve null", func() {
+				expr, err := expressions.Compile("null")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(BeNil())
+			})
+
+			g.It("should compare variables with literals", func() {
+				resolver := expressions.NewMapResolver(map[string]interface{}{
+					"flag":  true,
+					"empty": nil,
+				})
+				expr, err := expressions.Compile("flag == true && empty == null && flag != null")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should resolve !", func() {
+				resolver := expressions.NewMapResolver(map[string]interface{}{
+					"flag": false,
+				})
+				expr, err := expressions.Compile("!flag")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should give ! precedence over the other operators", func() {
+				expr, err := expressions.Compile("!false == true && !!true")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should not confuse ! with !=", func() {
+				expr, err := expressions.Compile("1 != !true")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+		})
+
+		g.Describe("Strings", func() {
+			resolver := expressions.NewMapResolver(map[string]interface{}{
+				"first": "Ann",
+				"last":  "Lee",
+				"n":     int64(1),
+			})
+			solve := func(s string) (interface{}, error) {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				return expr.Solve(expressions.NewContext(resolver, nil))
+			}
+
+			g.It("should decode escape sequences", func() {
+				for s, expected := range map[string]string{
+					`"a\"b"`:              `a"b`,
+					`"line\nnext\ttab"`:   "line\nnext\ttab",
+					`"caf\u00e9"`:         "café",
+					`"\ud83d\ude00"`:      "\U0001F600",
+					`"back\\slash\/"`:    `back\slash/`,
+					`"keep \d and \u12"`: `keep \d and \u12`,
+					`"ünïcödé"`:           "ünïcödé",
+				} {
+					v, err := solve(s)
+					Expect(err).To(BeNil(), s)
+					Expect(v).To(Equal(expected), s)
+				}
+			})
+
+			g.It("should accept single quoted strings", func() {
+				for s, expected := range map[string]string{
+					`'single'`:        "single",
+					`'it\'s'`:         "it's",
+					`'say "hi"'`:      `say "hi"`,
+					`"it's"`:          "it's",
+					`'a\nb' + "\u0021"`: "a\nb!",
+				} {
+					v, err := solve(s)
+					Expect(err).To(BeNil(), s)
+					Expect(v).To(Equal(expected), s)
+				}
+			})
+
+			g.It("should concatenate strings with +", func() {
+				v, err := solve(`first + " " + last`)
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal("Ann Lee"))
+
+				v, err = solve(`"" + ""`)
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(""))
+			})
+
+			g.It("should not mix strings with numbers", func() {
+				for _, s := range []string{`first + n`, `n + first`, `first - "A"`, `first * 2`, `first + last - "x"`, `-first`} {
+					_, err := solve(s)
+					var runtimeErr *expressions.RuntimeError
+					Expect(errors.As(err, &runtimeErr)).To(BeTrue(), s)
+					Expect(runtimeErr.Kind).To(Equal("arithmetic"), s)
+				}
+			})
+
+			g.It("should compare strings lexicographically", func() {
+				for s, expected := range map[string]bool{
+					`"apple" < "banana"`: true,
+					`"apple" < "Apple"`:  false,
+					`"ab" < "abc"`:       true,
+					`first >= "Ann"`:     true,
+					`last <= first`:      false,
+					`"é" > "z"`:          true,
+				} {
+					v, err := solve(s)
+					Expect(err).To(BeNil(), s)
+					Expect(v).To(Equal(expected), s)
+				}
+
+				_, err := solve(`first < n`)
+				var runtimeErr *expressions.RuntimeError
+				Expect(errors.As(err, &runtimeErr)).To(BeTrue())
+				Expect(runtimeErr.Kind).To(Equal("comparison"))
+			})
+		})
+
+		g.Describe("Relational operators", func() {
+			g.It("should resolve >=", func() {
+				expr, err := expressions.Compile("2 >= 2")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should resolve <=", func() {
+				expr, err := expressions.Compile("3 <= 2")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(false))
+			})
+
+			g.It("should compare arbitrary sub-expressions", func() {
+				resolver := expressions.NewMapResolver(map[string]interface{}{
+					"x": 4,
+					"y": 2,
+				})
+				expr, err := expressions.Compile("x + 1 > y * 2")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should give relational operators precedence over equality", func() {
+				expr, err := expressions.Compile("1 < 2 == 3 < 4")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should give equality precedence over logical operators", func() {
+				expr, err := expressions.Compile("1 + 1 == 2 && 2 * 3 != 5")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should compare functions", func() {
+				expr, err := expressions.Compile("cos(0) >= 1")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, &expressions.DefaultFunctions{}))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+		})
+
+		g.Describe("Conditional operator", func() {
+			resolver := expressions.NewMapResolver(map[string]interface{}{
+				"a":    int64(3),
+				"flag": true,
+			})
+
+			g.It("should solve only the chosen branch", func() {
+				expr, err := expressions.Compile("flag ? a : missing")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(int64(3)))
+
+				expr, err = expressions.Compile("!flag ? missing : \"no\"")
+				Expect(err).To(BeNil())
+				v, err = expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal("no"))
+			})
+
+			g.It("should have a lower precedence than the other operators", func() {
+				expr, err := expressions.Compile("a > 1 || !flag ? a + 1 : a - 1")
+				Expect(err).To(BeNil())
+				conditional := expr.(*expressions.ExpressionConditional)
+				Expect(conditional.Condition()).To(BeAssignableToTypeOf(&expressions.ExpressionLogical{}))
+				Expect(conditional.Then()).To(BeAssignableToTypeOf(&expressions.ExpressionMultiple{}))
+				Expect(conditional.Else()).To(BeAssignableToTypeOf(&expressions.ExpressionMultiple{}))
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(int64(4)))
+			})
+
+			g.It("should be right associative", func() {
+				expr, err := expressions.Compile("a > 5 ? \"big\" : a > 2 ? \"medium\" : \"small\"")
+				Expect(err).To(BeNil())
+				Expect(expr.(*expressions.ExpressionConditional).Else()).To(BeAssignableToTypeOf(&expressions.ExpressionConditional{}))
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal("medium"))
+
+				expr, err = expressions.Compile("flag ? a > 5 ? 1 : 2 : 3")
+				Expect(err).To(BeNil())
+				v, err = expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(int64(2)))
+			})
+
+			g.It("should keep the span of the operator", func() {
+				expr, err := expressions.Compile("1 + (flag ? a : 0)")
+				Expect(err).To(BeNil())
+				terms := expr.(*expressions.ExpressionMultiple).Terms()
+				span := terms[1].Expression().(expressions.Spanned).Span()
+				Expect([]int{span.Start, span.End, span.Line, span.Column}).To(Equal([]int{5, 17, 1, 5}))
+				Expect(span.Text()).To(Equal("flag ? a : 0"))
+			})
+
+			g.It("should report the missing colon", func() {
+				_, err := expressions.Compile("flag ? a")
+				Expect(fmt.Sprint(err)).To(ContainSubstring("mismatched input '<EOF>' expecting ':'"))
+			})
+		})
+
+		g.It("should compare variables with numeric literals of another kind", func() {
+			resolver := expressions.NewMapResolver(map[string]interface{}{
+				"x": int(1),
+				"y": float32(2.5),
+			})
+			expr, err := expressions.Compile("x == 1.0 && y != 2 && y == 2.5")
+			Expect(err).To(BeNil())
+			v, err := expr.Solve(expressions.NewContext(resolver, nil))
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(true))
+		})
+
+		g.Describe("Logical operators", func() {
+			g.It("should resolve &&", func() {
+				resolver := expressions.NewMapResolver(map[string]interface{}{
+					"a": 2,
+					"b": 1,
+				})
+				expr, err := expressions.Compile("a > 1 && b < 2")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should resolve ||", func() {
+				expr, err := expressions.Compile("1 == 2 || 2 == 2")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should resolve xor", func() {
+				expr, err := expressions.Compile("1 == 1 xor 2 == 2")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(false))
+			})
+
+			g.It("should give && precedence over ||", func() {
+				expr, err := expressions.Compile("1 == 1 || 1 == 2 && 1 == 2")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should short-circuit ||", func() {
+				expr, err := expressions.Compile("1 == 1 || unexistent(1)")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, &expressions.DefaultFunctions{}))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+
+			g.It("should short-circuit &&", func() {
+				expr, err := expressions.Compile("1 == 2 && unexistent(1)")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(nil, &expressions.DefaultFunctions{}))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(false))
+			})
+		})
+
 		g.Describe("Functions", func() {
 			g.It("should resolve a function 'acos'", func() {
 				expr, err := expressions.Compile("cos(0.1)")
@@ -301,7 +1119,7 @@ func TestCompile(t *testing.T) {
 				Expect(expr).NotTo(BeNil())
 				v, err := expr.Solve(expressions.NewContext(nil, &expressions.DefaultFunctions{}))
 				Expect(err).To(BeNil())
-				Expect(v).To(Equal(float64(2)))
+				Expect(v).To(Equal(int64(2)))
 			})
 
 			g.It("should resolve a function 'if' (false statement)", func() {
@@ -310,7 +1128,7 @@ func TestCompile(t *testing.T) {
 				Expect(expr).NotTo(BeNil())
 				v, err := expr.Solve(expressions.NewContext(nil, &expressions.DefaultFunctions{}))
 				Expect(err).To(BeNil())
-				Expect(v).To(Equal(float64(3)))
+				Expect(v).To(Equal(int64(3)))
 			})
 
 			g.It("should resolve a function 'if' return string (true statement)", func() {
@@ -339,5 +1157,71 @@ func TestCompile(t *testing.T) {
 				Expect(err).NotTo(BeNil())
 			})
 		})
+
+		g.Describe("Native parser", func() {
+			compiler := expressions.NewCompiler().SetConstant("answer", int64(42))
+
+			g.It("should build the same trees of the ANTLR parser", func() {
+				for _, s := range []string{
+					"1", "1.5", "2e10", "1.5E-3", "1e2.5", "18446744073709551615", "99999999999999999999",
+					"pi", "e", "i", "answer", "x", "_var1", "true", "false", "null", "\"str\"", "\"a\\\"b\"", "\"\"",
+					"-1", "+x", "!x", "- -x", "!!true", "-(1 + 2)",
+					"1 + 2 - 3", "1 * 2 / 3 % 4", "2 ^ 3 ^ 2", "1 + 2 * 3 ^ 4 - -5",
+					"a > b", "a < b", "a >= b", "a <= b", "a == b != c", "1 < 2 == 3 > 4",
+					"a && b || c xor d", "a || b || c", "a && (b || c)", "!(a && b) xor c",
+					"((((x))))", "(x) + (y)", "  x\t+\n y  ",
+					"f(1)", "sqrt(2, 3)", "if(a > 1, \"yes\", f(g(x), -1))",
+					"order.customer.name", "order.items[0].price * 2", "m[\"key\"]", "a[b[c]]",
+					"(a + b).c", "f(x).y[1]", "x.pi.e.i.true.false.null", "-a.b", "!a[1]",
+					"1.x", "1.5.x",
+					"a ? b : c", "a ? b : c ? d : e", "a ? b ? c : d : e", "a || b ? c + 1 : -d", "(a ? b : c).d",
+					"f(a ? 1 : 2, b)", "x[a ? 0 : 1]", "!a ? b : c",
+					"'single'", "'it\\'s'", "\"a\\nb\\u00e9\"", "'a' + \"b\" < 'c'", "\"\\\\\"",
+				} {
+					native, err := compiler.Compile(s)
+					Expect(err).To(BeNil(), s)
+					generated, err := compiler.CompileANTLR(s)
+					Expect(err).To(BeNil(), s)
+					Expect(native).To(Equal(generated), s)
+				}
+			})
+
+			g.It("should report the same syntax errors of the ANTLR parser", func() {
+				for _, s := range []string{
+					"", ")", "1 2", "a b c", "(1 + 2", "1 + (2 * 3", "1 + * 2 + (3 - )", "(1 +) * 3",
+					"a[1", "a.", "a.+", "f(,1)", "\"abc", "x +\n\t* 3", "1 # 2", "a = b", "!", "ée", "1e", "3e2x",
+					"a ?", "a ? b", "a ? b c", "a ? b ) c", "a ? : c", "a : b", "a ? b : ", "(a ? b) + c", "f(a ? b, c)",
+					"'abc", "'ab\ncd", "x + 'a\\", "a =b", "a |b", "a =\nb",
+				} {
+					_, nativeErr := compiler.Compile(s)
+					Expect(nativeErr).To(BeAssignableToTypeOf(&expressions.CompileError{}), s)
+					_, generatedErr := compiler.CompileANTLR(s)
+					Expect(generatedErr).To(BeAssignableToTypeOf(&expressions.CompileError{}), s)
+					Expect(nativeErr).To(Equal(generatedErr), s)
+				}
+			})
+
+			g.It("should reject the same inputs of the ANTLR parser", func() {
+				for _, s := range []string{"f(1, 2", "f()", "a..b", "1 +", "[1]", "a ^^ b", "a.xor", "\"x\"y\"", "a | b & c", "\"ab\\\"", "\"a\\\nb\""} {
+					_, nativeErr := compiler.Compile(s)
+					Expect(nativeErr).NotTo(BeNil(), s)
+					_, generatedErr := compiler.CompileANTLR(s)
+					Expect(generatedErr).NotTo(BeNil(), s)
+				}
+			})
+		})
 	})
 }
+
+func BenchmarkCompile(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		expressions.Compile("if(order.total > 100 && customer.vip, order.total * 0.9, order.total) + sqrt(2) ^ 2")
+	}
+}
+
+func BenchmarkCompileANTLR(b *testing.B) {
+	compiler := expressions.NewCompiler()
+	for i := 0; i < b.N; i++ {
+		compiler.CompileANTLR("if(order.total > 100 && customer.vip, order.total * 0.9, order.total) + sqrt(2) ^ 2")
+	}
+}
@@ -0,0 +1,313 @@
+package expressions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Policy restricts the functions and variables expressions can use, by name
+// or by pattern. In patterns, `*` matches any sequence of characters.
+//
+// A name is allowed when it does not match any denied pattern and, if there
+// are allowed patterns, matches one of them. Variables are checked with the
+// paths they are accessed through: `order.customer.name` is allowed by the
+// pattern `order` as well as by `order.customer.*`, and denied by the same.
+type Policy struct {
+	functions rules
+	variables rules
+}
+
+type rules struct {
+	allow []string
+	deny  []string
+}
+
+func NewPolicy() *Policy {
+	return &Policy{}
+}
+
+func (p *Policy) AllowFunctions(patterns ...string) *Policy {
+	p.functions.allow = append(p.functions.allow, patterns...)
+	return p
+}
+
+func (p *Policy) DenyFunctions(patterns ...string) *Policy {
+	p.functions.deny = append(p.functions.deny, patterns...)
+	return p
+}
+
+func (p *Policy) AllowVariables(patterns ...string) *Policy {
+	p.variables.allow = append(p.variables.allow, patterns...)
+	return p
+}
+
+func (p *Policy) DenyVariables(patterns ...string) *Policy {
+	p.variables.deny = append(p.variables.deny, patterns...)
+	return p
+}
+
+func (p *Policy) FunctionAllowed(name string) bool {
+	return p.functions.allowed([]string{name})
+}
+
+// VariableAllowed reports whether a variable, or a path of members and
+// indexes accessed on it, is allowed.
+func (p *Policy) VariableAllowed(path string) bool {
+	return p.variables.allowed(pathPrefixes(path))
+}
+
+// Check fails with a PolicyError for the first function or variable of an
+// expression that is not allowed. Indexes by constant strings are checked as
+// members: `user["password"]` is checked as `user.password`. Paths are checked
+// up to the first index only known when solving, which is not allowed when
+// the policy denies what can be under it: `items[n].secret` is checked as
+// `items[...]`.
+//
+// Values used whole, as the result, the parameters of functions or what
+// members and indexes are accessed on, through conditionals or not, cannot
+// have anything denied under them either, since what is done with them is not
+// known: with `user.password` denied, `user.name` is allowed but `user` and
+// `format("{0}", user)` are not. Patterns starting with `*` can match under
+// any variable, so with them variables can only be used by operators.
+func (p *Policy) Check(expression Expression) error {
+	return p.check(expression, true)
+}
+
+// check checks e, which is used whole when whole is set.
+func (p *Policy) check(e Expression, whole bool) error {
+	switch ee := e.(type) {
+	case *ExpressionField, *ExpressionMember, *ExpressionIndex:
+		if path, ok := policyPath(e); ok {
+			if !p.VariableAllowed(path) || (whole && p.variables.deniesBelow(path)) {
+				return newPolicyError("variable", path, e)
+			}
+			return nil
+		}
+		if index, ok := ee.(*ExpressionIndex); ok {
+			if path, ok := policyPath(index.expression); ok && p.variables.deniesBelow(path) {
+				return newPolicyError("variable", path+"[...]", e)
+			}
+		}
+	case *ExpressionFunction:
+		if !p.FunctionAllowed(ee.name) {
+			return newPolicyError("function", ee.name, e)
+		}
+	}
+	for i, c := range children(e) {
+		if err := p.check(c, usesWhole(e, i, whole)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// usesWhole reports whether the i-th child of e, as children returns them, is
+// used whole, when e is used whole or not as given. Operators only use their
+// operands to compute new values.
+func usesWhole(e Expression, i int, whole bool) bool {
+	switch e.(type) {
+	case *ExpressionFunction:
+		return true
+	case *ExpressionMember, *ExpressionIndex:
+		return i == 0
+	case *ExpressionBrackets:
+		return whole
+	case *ExpressionConditional:
+		return i > 0 && whole
+	}
+	return false
+}
+
+// Functions wraps functions so only the ones allowed can be called.
+func (p *Policy) Functions(functions Functions) Functions {
+	return &policyFunctions{
+		policy:    p,
+		functions: functions,
+	}
+}
+
+// Resolver wraps a resolver so only the variables allowed can be resolved.
+// Resolvers are asked for variables, not for the members accessed on them,
+// which are only checked by Check.
+func (p *Policy) Resolver(resolver Resolver) Resolver {
+	return &policyResolver{
+		policy:   p,
+		resolver: resolver,
+	}
+}
+
+// PolicyError is returned when an expression uses a function or a variable a
+// Policy does not allow. Kind is "function" or "variable". Span is set by
+// Check.
+type PolicyError struct {
+	Kind string
+	Name string
+	Span *Span
+}
+
+func newPolicyError(kind string, name string, e Expression) *PolicyError {
+	err := &PolicyError{
+		Kind: kind,
+		Name: name,
+	}
+	if s, ok := e.(Spanned); ok {
+		err.Span = s.Span()
+	}
+	return err
+}
+
+func (err *PolicyError) Error() string {
+	if err.Span != nil {
+		return fmt.Sprintf("The %s '%s' is not allowed (%s).", err.Kind, err.Name, err.Span)
+	}
+	return fmt.Sprintf("The %s '%s' is not allowed.", err.Kind, err.Name)
+}
+
+type policyFunctions struct {
+	policy    *Policy
+	functions Functions
+}
+
+func (f *policyFunctions) Call(ctx Context, name string, params ...Expression) (interface{}, error) {
+	if !f.policy.FunctionAllowed(name) {
+		return nil, &PolicyError{Kind: "function", Name: name}
+	}
+	return f.functions.Call(ctx, name, params...)
+}
+
+type policyResolver struct {
+	policy   *Policy
+	resolver Resolver
+}
+
+func (r *policyResolver) Resolve(name string) (interface{}, error) {
+	return r.ResolveContext(context.Background(), name)
+}
+
+func (r *policyResolver) ResolveContext(ctx context.Context, name string) (interface{}, error) {
+	if !r.policy.VariableAllowed(name) {
+		return nil, &PolicyError{Kind: "variable", Name: name}
+	}
+	if resolver, ok := r.resolver.(ContextResolver); ok {
+		return resolver.ResolveContext(ctx, name)
+	}
+	return r.resolver.Resolve(name)
+}
+
+// policyPath renders the path of fields accessed through members and constant
+// indexes, with the indexes by strings as members. It fails for anything else.
+func policyPath(e Expression) (string, bool) {
+	switch ee := e.(type) {
+	case *ExpressionField:
+		return ee.field, true
+	case *ExpressionMember:
+		if path, ok := policyPath(ee.expression); ok {
+			return path + "." + ee.name, true
+		}
+	case *ExpressionIndex:
+		if index, ok := ee.index.(*ExpressionValue); ok {
+			if path, ok := policyPath(ee.expression); ok {
+				if name, ok := index.value.(string); ok {
+					return path + "." + name, true
+				}
+				return fmt.Sprintf("%s[%#v]", path, index.value), true
+			}
+		}
+	case *ExpressionBrackets:
+		return policyPath(ee.inner)
+	}
+	return "", false
+}
+
+// allowed reports whether a name, given with its prefixes, is allowed by the
+// rules: none of them can be denied and one of them must be allowed.
+func (r *rules) allowed(names []string) bool {
+	for _, name := range names {
+		if matchAny(r.deny, name) {
+			return false
+		}
+	}
+	if len(r.allow) == 0 {
+		return true
+	}
+	for _, name := range names {
+		if matchAny(r.allow, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// deniesBelow reports whether the rules deny members or indexes of path, so
+// what is accessed on it must be known to tell whether it is allowed.
+// Patterns that are allowed are not considered: what is under a path is only
+// allowed by them when the path is.
+func (r *rules) deniesBelow(path string) bool {
+	for _, pattern := range r.deny {
+		if matchesBelow(pattern, path+".") || matchesBelow(pattern, path+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesBelow reports whether a pattern can match a name that starts with
+// prefix.
+func matchesBelow(pattern string, prefix string) bool {
+	first := strings.Split(pattern, "*")[0]
+	if len(first) == len(pattern) {
+		return strings.HasPrefix(pattern, prefix)
+	}
+	return strings.HasPrefix(first, prefix) || strings.HasPrefix(prefix, first)
+}
+
+// pathPrefixes returns a path and the paths it is accessed through, e.g.
+// `a`, `a.b` and `a.b[0]` for `a.b[0]`.
+func pathPrefixes(path string) []string {
+	var r []string
+	brackets := 0
+	for i, c := range path {
+		switch {
+		case c == ']':
+			brackets--
+		case brackets > 0:
+		case c == '.' || c == '[':
+			r = append(r, path[:i])
+		}
+		if c == '[' {
+			brackets++
+		}
+	}
+	return append(r, path)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern matches a name against a pattern where `*` matches any
+// sequence of characters.
+func matchPattern(pattern string, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
@@ -0,0 +1,164 @@
+package expressions_test
+
+import (
+	"errors"
+	"testing"
+	"github.com/jamillosantos/go-expressions"
+	. "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestPolicy(t *testing.T) {
+	g := Goblin(t)
+
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Policy", func() {
+		g.It("should match names and patterns", func() {
+			policy := expressions.NewPolicy().
+				AllowFunctions("sqrt", "str_*").
+				DenyFunctions("str_exec").
+				AllowVariables("order", "user.name", "tenant_*").
+				DenyVariables("order.*.secret", "tenant_internal")
+
+			Expect(policy.FunctionAllowed("sqrt")).To(BeTrue())
+			Expect(policy.FunctionAllowed("str_upper")).To(BeTrue())
+			Expect(policy.FunctionAllowed("str_exec")).To(BeFalse())
+			Expect(policy.FunctionAllowed("cos")).To(BeFalse())
+
+			Expect(policy.VariableAllowed("order")).To(BeTrue())
+			Expect(policy.VariableAllowed("order.items[0].price")).To(BeTrue())
+			Expect(policy.VariableAllowed("order.customer.secret")).To(BeFalse())
+			Expect(policy.VariableAllowed("order.customer.secret.length")).To(BeFalse())
+			Expect(policy.VariableAllowed("user")).To(BeFalse())
+			Expect(policy.VariableAllowed("user.name")).To(BeTrue())
+			Expect(policy.VariableAllowed("user.password")).To(BeFalse())
+			Expect(policy.VariableAllowed("tenant_id")).To(BeTrue())
+			Expect(policy.VariableAllowed("tenant_internal")).To(BeFalse())
+			Expect(policy.VariableAllowed("orders")).To(BeFalse())
+		})
+
+		g.It("should allow everything not denied without allowed patterns", func() {
+			policy := expressions.NewPolicy().DenyVariables("password").DenyFunctions("*exec*")
+			Expect(policy.VariableAllowed("user.name")).To(BeTrue())
+			Expect(policy.VariableAllowed("password")).To(BeFalse())
+			Expect(policy.FunctionAllowed("cos")).To(BeTrue())
+			Expect(policy.FunctionAllowed("do_exec_now")).To(BeFalse())
+		})
+
+		g.It("should check compiled expressions", func() {
+			policy := expressions.NewPolicy().
+				AllowFunctions("if", "sqrt").
+				DenyVariables("user.password", "secret")
+
+			expr, err := expressions.Compile("if(user.name == \"x\", sqrt(a), items[n].password)")
+			Expect(err).To(BeNil())
+			Expect(policy.Check(expr)).To(BeNil())
+
+			expr, err = expressions.Compile("user[\"name\"] + items[0][\"password\"] + user.tags[n]")
+			Expect(err).To(BeNil())
+			Expect(policy.Check(expr)).To(BeNil())
+
+			expr, err = expressions.Compile("a + user[\"password\"]")
+			Expect(err).To(BeNil())
+			err = policy.Check(expr)
+			Expect(err).To(BeAssignableToTypeOf(&expressions.PolicyError{}))
+			Expect(err.(*expressions.PolicyError).Name).To(Equal("user.password"))
+			Expect(err.(*expressions.PolicyError).Span.Text()).To(Equal("user[\"password\"]"))
+
+			expr, err = expressions.Compile("a + user[\"pass\" + \"word\"]")
+			Expect(err).To(BeNil())
+			err = policy.Check(expr)
+			Expect(err).To(BeAssignableToTypeOf(&expressions.PolicyError{}))
+			Expect(err.(*expressions.PolicyError).Name).To(Equal("user[...]"))
+
+			err = expressions.NewPolicy().DenyVariables("*.secret").Check(expr)
+			Expect(err).To(BeAssignableToTypeOf(&expressions.PolicyError{}))
+			Expect(err.(*expressions.PolicyError).Name).To(Equal("user[...]"))
+
+			expr, err = expressions.Compile("a + cos(b)")
+			Expect(err).To(BeNil())
+			err = policy.Check(expr)
+			Expect(err).To(BeAssignableToTypeOf(&expressions.PolicyError{}))
+			Expect(err.(*expressions.PolicyError).Kind).To(Equal("function"))
+			Expect(err.(*expressions.PolicyError).Name).To(Equal("cos"))
+			Expect(err.(*expressions.PolicyError).Span.Text()).To(Equal("cos(b)"))
+			Expect(err.Error()).To(Equal("The function 'cos' is not allowed (`cos(b)` at 1:4)."))
+
+			expr, err = expressions.Compile("a > 1 && user.password[0] == \"x\"")
+			Expect(err).To(BeNil())
+			err = policy.Check(expr)
+			Expect(err).To(BeAssignableToTypeOf(&expressions.PolicyError{}))
+			Expect(err.(*expressions.PolicyError).Kind).To(Equal("variable"))
+			Expect(err.(*expressions.PolicyError).Name).To(Equal("user.password[0]"))
+		})
+
+		g.It("should reject variables used whole when something under them is denied", func() {
+			policy := expressions.NewPolicy().DenyVariables("user.password")
+			for _, s := range []string{
+				"user", "(user)", "(true ? user : user).password", "if(true, user, 0).password",
+				"format(\"{0}\", user)", "a > 1 ? user : null",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				err = policy.Check(expr)
+				Expect(err).To(BeAssignableToTypeOf(&expressions.PolicyError{}), s)
+				Expect(err.(*expressions.PolicyError).Name).To(Equal("user"), s)
+			}
+			for _, s := range []string{
+				"user.name", "user.name == \"x\" ? a : b", "if(user.active, user.name, \"\")",
+				"format(\"{0}\", user.name)", "user != null && len(user.name) > 0",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				Expect(policy.Check(expr)).To(BeNil(), s)
+			}
+		})
+
+		g.It("should make the compiler reject what it does not allow", func() {
+			compiler := expressions.NewCompiler().SetPolicy(expressions.NewPolicy().DenyVariables("secret"))
+			_, err := compiler.Compile("a + b")
+			Expect(err).To(BeNil())
+			_, err = compiler.Compile("a + secret")
+			Expect(err).To(BeAssignableToTypeOf(&expressions.PolicyError{}))
+			policyErr := err.(*expressions.PolicyError)
+			Expect([]string{policyErr.Kind, policyErr.Name}).To(Equal([]string{"variable", "secret"}))
+			Expect([]int{policyErr.Span.Start, policyErr.Span.End, policyErr.Span.Line, policyErr.Span.Column}).To(Equal([]int{4, 10, 1, 4}))
+			Expect(policyErr.Span.Text()).To(Equal("secret"))
+			for _, s := range []string{"user[\"secret\"]", "user[\"sec\" + \"ret\"].length"} {
+				_, err = expressions.NewCompiler().SetPolicy(expressions.NewPolicy().DenyVariables("user.secret")).Compile(s)
+				Expect(err).To(BeAssignableToTypeOf(&expressions.PolicyError{}), s)
+			}
+			_, err = compiler.CompileANTLR("a + secret")
+			Expect(err).To(BeAssignableToTypeOf(&expressions.PolicyError{}))
+		})
+
+		g.It("should enforce the policy when solving", func() {
+			policy := expressions.NewPolicy().AllowFunctions("sqrt").DenyVariables("secret")
+			resolver := expressions.NewMapResolver(map[string]interface{}{
+				"a":      int64(16),
+				"secret": "s3cr3t",
+			})
+			ctx := expressions.NewContext(policy.Resolver(resolver), policy.Functions(&expressions.DefaultFunctions{}))
+
+			expr, err := expressions.Compile("sqrt(a)")
+			Expect(err).To(BeNil())
+			v, err := expr.Solve(ctx)
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(4.0))
+
+			var policyErr *expressions.PolicyError
+			expr, err = expressions.Compile("cos(a)")
+			Expect(err).To(BeNil())
+			_, err = expr.Solve(ctx)
+			Expect(errors.As(err, &policyErr)).To(BeTrue())
+			Expect(policyErr).To(Equal(&expressions.PolicyError{Kind: "function", Name: "cos"}))
+
+			expr, err = expressions.Compile("a + secret")
+			Expect(err).To(BeNil())
+			_, err = expressions.NewProgram(expr).Solve(ctx)
+			Expect(errors.As(err, &policyErr)).To(BeTrue())
+			Expect(policyErr).To(Equal(&expressions.PolicyError{Kind: "variable", Name: "secret"}))
+		})
+	})
+}
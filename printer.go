@@ -0,0 +1,462 @@
+package expressions
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Precedences of the rules of Expression.g4, from the lowest to the highest.
+// All binary operators are left associative, the conditional operator is
+// right associative.
+const (
+	precedenceConditional = iota
+	precedenceOr
+	precedenceXor
+	precedenceAnd
+	precedenceEquality
+	precedenceRelational
+	precedenceAdditive
+	precedenceMultiplicative
+	precedencePow
+	precedenceUnary
+	precedenceAtom
+)
+
+var precedences = map[string]int{
+	"||":  precedenceOr,
+	"xor": precedenceXor,
+	"&&":  precedenceAnd,
+	"==":  precedenceEquality,
+	"!=":  precedenceEquality,
+	">":   precedenceRelational,
+	"<":   precedenceRelational,
+	">=":  precedenceRelational,
+	"<=":  precedenceRelational,
+	"+":   precedenceAdditive,
+	"-":   precedenceAdditive,
+	"*":   precedenceMultiplicative,
+	"/":   precedenceMultiplicative,
+	"%":   precedenceMultiplicative,
+	"^":   precedencePow,
+}
+
+// Printer renders expressions back to source. The source is canonical: it
+// only has the parentheses the precedence of the operators requires and
+// compiling it gives an expression that solves the same way.
+//
+// Lines longer than the width are broken before the operators and around the
+// parameters of functions, indenting what is nested. A width of 0 renders
+// everything in a single line.
+type Printer struct {
+	width  int
+	indent string
+}
+
+// NewPrinter creates a printer that breaks lines longer than 80 characters,
+// indenting them with two spaces.
+func NewPrinter() *Printer {
+	return &Printer{
+		width:  80,
+		indent: "  ",
+	}
+}
+
+func (p *Printer) SetWidth(width int) *Printer {
+	p.width = width
+	return p
+}
+
+func (p *Printer) SetIndent(indent string) *Printer {
+	p.indent = indent
+	return p
+}
+
+// Pretty renders an expression with the default Printer.
+func Pretty(expression Expression) string {
+	return NewPrinter().Print(expression)
+}
+
+func (p *Printer) Print(expression Expression) string {
+	return (&printing{
+		Printer: p,
+		widths:  make(map[Expression]int),
+	}).print(expression, 0)
+}
+
+func (e *ExpressionValue) String() string       { return format(e) }
+func (e *ExpressionField) String() string       { return format(e) }
+func (e *ExpressionMember) String() string      { return format(e) }
+func (e *ExpressionIndex) String() string       { return format(e) }
+func (e *ExpressionMultiple) String() string    { return format(e) }
+func (e *ExpressionBinary) String() string      { return format(e) }
+func (e *ExpressionLogical) String() string     { return format(e) }
+func (e *ExpressionNot) String() string         { return format(e) }
+func (e *ExpressionBrackets) String() string    { return format(e) }
+func (e *ExpressionFunction) String() string    { return format(e) }
+func (e *ExpressionConditional) String() string { return format(e) }
+
+// format renders an expression in a single line.
+func format(expression Expression) string {
+	return (&Printer{}).Print(expression)
+}
+
+// printing is a call to Print. It keeps the width each node has in a single
+// line, which deciding where to break lines needs for every node, so the
+// widths are only measured once.
+type printing struct {
+	*Printer
+	widths map[Expression]int
+}
+
+// print renders an expression nested depth times.
+func (p *printing) print(expression Expression, depth int) string {
+	switch e := expression.(type) {
+	case nil:
+		return ""
+	case *ExpressionValue:
+		s, _, ok := formatValue(e.value)
+		if !ok && e.name != "" {
+			return e.name
+		}
+		return s
+	case *ExpressionField:
+		return e.field
+	case *ExpressionMember:
+		return p.operand(e.expression, precedenceAtom, depth) + "." + e.name
+	case *ExpressionIndex:
+		return p.operand(e.expression, precedenceAtom, depth) + "[" + p.print(e.index, depth) + "]"
+	case *ExpressionBrackets:
+		return p.print(e.inner, depth)
+	case *ExpressionNot:
+		return "!" + p.operand(e.expression, precedenceUnary, depth)
+	case *ExpressionMultiple:
+		if operator, operand, ok := unary(e); ok {
+			return operator + p.operand(operand, precedenceUnary, depth)
+		}
+		switch len(e.terms) {
+		case 0:
+			return "0"
+		case 1:
+			// A single term fails when it is not a number, as `x * 1` does.
+			return p.operand(e.terms[0].expression, precedenceMultiplicative, depth) + " * 1"
+		}
+		operands, operators := terms(e)
+		return p.chain(expression, operands, operators, depth)
+	case *ExpressionBinary:
+		return p.chain(expression, []Expression{e.left, e.right}, []string{"", e.operator}, depth)
+	case *ExpressionLogical:
+		return p.chain(expression, []Expression{e.left, e.right}, []string{"", e.operator}, depth)
+	case *ExpressionFunction:
+		params := make([]string, len(e.params))
+		for i, param := range e.params {
+			params[i] = p.print(param, depth+1)
+		}
+		if p.breaks(expression, depth) {
+			return e.name + "(" + p.newLine(depth+1) + strings.Join(params, ","+p.newLine(depth+1)) + p.newLine(depth) + ")"
+		}
+		return e.name + "(" + strings.Join(params, ", ") + ")"
+	case *ExpressionConditional:
+		separator := " "
+		if p.breaks(expression, depth) {
+			separator = p.newLine(depth + 1)
+		}
+		then := p.print(e.then, depth+1)
+		otherwise := p.print(e.otherwise, depth+1)
+		return p.operand(e.condition, precedenceOr, depth) + separator + "? " + then + separator + ": " + otherwise
+	}
+	return fmt.Sprint(expression)
+}
+
+// chain renders operands separated by left associative operators. Operators
+// of a higher precedence than the ones before them, only found in trees built
+// by hand, put what is before them in parentheses.
+func (p *printing) chain(expression Expression, operands []Expression, operators []string, depth int) string {
+	separator := " "
+	if p.breaks(expression, depth) {
+		separator = p.newLine(depth)
+	}
+	precedence := precedences[operators[1]]
+	s := p.operand(operands[0], precedence, depth)
+	for i := 1; i < len(operands); i++ {
+		level := precedences[operators[i]]
+		if precedence < level {
+			s = "(" + s + ")"
+		}
+		s += separator + operators[i] + " " + p.operand(operands[i], level+1, depth+1)
+		precedence = level
+	}
+	return s
+}
+
+// operand renders an expression in parentheses when its precedence is lower
+// than the given one.
+func (p *printing) operand(expression Expression, precedence int, depth int) string {
+	if precedenceOf(expression) >= precedence {
+		return p.print(expression, depth)
+	}
+	if p.breaks(expression, depth+1) {
+		return "(" + p.newLine(depth+1) + p.print(expression, depth+1) + p.newLine(depth) + ")"
+	}
+	return "(" + p.print(expression, depth) + ")"
+}
+
+// breaks reports whether an expression does not fit in a line when nested
+// depth times.
+func (p *printing) breaks(expression Expression, depth int) bool {
+	if p.width <= 0 {
+		return false
+	}
+	return depth*utf8.RuneCountInString(p.indent)+p.flatWidth(expression) > p.width
+}
+
+// flatWidth returns the width of an expression rendered in a single line. The
+// widths of the nodes with operands are kept, since every node asks for them.
+func (p *printing) flatWidth(expression Expression) int {
+	switch expression.(type) {
+	case *ExpressionMember, *ExpressionIndex, *ExpressionBrackets, *ExpressionNot, *ExpressionMultiple,
+		*ExpressionBinary, *ExpressionLogical, *ExpressionFunction, *ExpressionConditional:
+		if w, ok := p.widths[expression]; ok {
+			return w
+		}
+		w := p.measure(expression)
+		p.widths[expression] = w
+		return w
+	}
+	return p.measure(expression)
+}
+
+// measure does what print does in a single line, counting the characters
+// instead of rendering them.
+func (p *printing) measure(expression Expression) int {
+	switch e := expression.(type) {
+	case *ExpressionMember:
+		return p.operandWidth(e.expression, precedenceAtom) + 1 + utf8.RuneCountInString(e.name)
+	case *ExpressionIndex:
+		return p.operandWidth(e.expression, precedenceAtom) + p.flatWidth(e.index) + 2
+	case *ExpressionBrackets:
+		return p.flatWidth(e.inner)
+	case *ExpressionNot:
+		return 1 + p.operandWidth(e.expression, precedenceUnary)
+	case *ExpressionMultiple:
+		if operator, operand, ok := unary(e); ok {
+			return len(operator) + p.operandWidth(operand, precedenceUnary)
+		}
+		switch len(e.terms) {
+		case 0:
+			return 1
+		case 1:
+			return p.operandWidth(e.terms[0].expression, precedenceMultiplicative) + 4
+		}
+		return p.chainWidth(terms(e))
+	case *ExpressionBinary:
+		return p.chainWidth([]Expression{e.left, e.right}, []string{"", e.operator})
+	case *ExpressionLogical:
+		return p.chainWidth([]Expression{e.left, e.right}, []string{"", e.operator})
+	case *ExpressionFunction:
+		w := utf8.RuneCountInString(e.name) + 2
+		for i, param := range e.params {
+			if i > 0 {
+				w += 2
+			}
+			w += p.flatWidth(param)
+		}
+		return w
+	case *ExpressionConditional:
+		return p.operandWidth(e.condition, precedenceOr) + p.flatWidth(e.then) + p.flatWidth(e.otherwise) + 6
+	}
+	return utf8.RuneCountInString(p.print(expression, 0))
+}
+
+func (p *printing) chainWidth(operands []Expression, operators []string) int {
+	precedence := precedences[operators[1]]
+	w := p.operandWidth(operands[0], precedence)
+	for i := 1; i < len(operands); i++ {
+		level := precedences[operators[i]]
+		if precedence < level {
+			w += 2
+		}
+		w += len(operators[i]) + 2 + p.operandWidth(operands[i], level+1)
+		precedence = level
+	}
+	return w
+}
+
+func (p *printing) operandWidth(expression Expression, precedence int) int {
+	if precedenceOf(expression) >= precedence {
+		return p.flatWidth(expression)
+	}
+	return p.flatWidth(expression) + 2
+}
+
+// precedenceOf returns the precedence of the text of an expression, which
+// tells whether it needs parentheses as an operand.
+func precedenceOf(expression Expression) int {
+	switch e := expression.(type) {
+	case nil, *ExpressionField, *ExpressionMember, *ExpressionIndex, *ExpressionFunction:
+		return precedenceAtom
+	case *ExpressionValue:
+		_, precedence, ok := formatValue(e.value)
+		if !ok && e.name != "" {
+			return precedenceAtom
+		}
+		return precedence
+	case *ExpressionBrackets:
+		return precedenceOf(e.inner)
+	case *ExpressionNot:
+		return precedenceUnary
+	case *ExpressionMultiple:
+		if _, _, ok := unary(e); ok {
+			return precedenceUnary
+		}
+		switch len(e.terms) {
+		case 0:
+			return precedenceAtom
+		case 1:
+			return precedenceMultiplicative
+		}
+		return precedences[e.terms[len(e.terms)-1].operator]
+	case *ExpressionBinary:
+		return precedences[e.operator]
+	case *ExpressionLogical:
+		return precedences[e.operator]
+	}
+	return precedenceConditional
+}
+
+func (p *printing) newLine(depth int) string {
+	return "\n" + strings.Repeat(p.indent, depth)
+}
+
+// terms returns the operands and the operators of the terms of e.
+func terms(e *ExpressionMultiple) ([]Expression, []string) {
+	operands := make([]Expression, len(e.terms))
+	operators := make([]string, len(e.terms))
+	for i, term := range e.terms {
+		operands[i], operators[i] = term.expression, term.operator
+	}
+	return operands, operators
+}
+
+// unary returns the operator and the operand of the trees the parser builds
+// for `-x` and `+x`, which add x to an untyped 0 instead of the int64 number
+// literals are compiled to.
+func unary(e *ExpressionMultiple) (string, Expression, bool) {
+	if len(e.terms) != 2 {
+		return "", nil, false
+	}
+	zero, ok := e.terms[0].expression.(*ExpressionValue)
+	if !ok || zero.value != 0 {
+		return "", nil, false
+	}
+	switch e.terms[1].operator {
+	case "+", "-":
+		return e.terms[1].operator, e.terms[1].expression, true
+	}
+	return "", nil, false
+}
+
+// formatValue renders a value as a literal. Infinities and NaN are rendered as
+// the divisions that give them. Values that have no literal are rendered with
+// fmt and the bool result is false, so constants can be rendered by name.
+func formatValue(value interface{}) (string, int, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "null", precedenceAtom, true
+	case bool:
+		return strconv.FormatBool(v), precedenceAtom, true
+	case string:
+		return quote(v), precedenceAtom, true
+	case float32:
+		return formatValue(float64(v))
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "0.0 / 0", precedenceMultiplicative, true
+		case math.IsInf(v, 1):
+			return "1.0 / 0", precedenceMultiplicative, true
+		case math.IsInf(v, -1):
+			return "-1.0 / 0", precedenceMultiplicative, true
+		}
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s, numberPrecedence(s), true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		s := fmt.Sprint(v)
+		return s, numberPrecedence(s), true
+	case complex64:
+		return formatValue(complex128(v))
+	case complex128:
+		return formatComplex(v)
+	}
+	return fmt.Sprint(value), precedenceAtom, false
+}
+
+// formatComplex renders a complex number with the constant i, e.g. `1.0 -
+// 2.0 * i`. Complex numbers with infinite or NaN parts have no literal.
+func formatComplex(c complex128) (string, int, bool) {
+	re, im := real(c), imag(c)
+	if math.IsInf(re, 0) || math.IsNaN(re) || math.IsInf(im, 0) || math.IsNaN(im) {
+		return fmt.Sprint(c), precedenceAtom, false
+	}
+	s, precedence := "i", precedenceAtom
+	if math.Abs(im) != 1 {
+		s, _, _ = formatValue(math.Abs(im))
+		s, precedence = s+" * i", precedenceMultiplicative
+	}
+	if re == 0 {
+		if im >= 0 {
+			return s, precedence, true
+		}
+		if precedence == precedenceAtom {
+			precedence = precedenceUnary
+		}
+		return "-" + s, precedence, true
+	}
+	r, _, _ := formatValue(re)
+	if im < 0 {
+		return r + " - " + s, precedenceAdditive, true
+	}
+	return r + " + " + s, precedenceAdditive, true
+}
+
+// quote renders a string literal in double quotes, escaping what cannot be
+// written in it as it is.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < ' ' || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// numberPrecedence tells negative numbers, which are compiled from the
+// unary minus, apart from the others.
+func numberPrecedence(s string) int {
+	if strings.HasPrefix(s, "-") {
+		return precedenceUnary
+	}
+	return precedenceAtom
+}
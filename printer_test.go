@@ -0,0 +1,225 @@
+package expressions_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"github.com/jamillosantos/go-expressions"
+	. "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestPrinter(t *testing.T) {
+	g := Goblin(t)
+
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("String", func() {
+		resolver := expressions.NewMapResolver(map[string]interface{}{
+			"a":     int64(3),
+			"b":     2.5,
+			"c":     int64(-4),
+			"name":  "john",
+			"flag":  true,
+			"list":  []interface{}{int64(1), "two", 3.0},
+			"order": &order{Customer: customer{Name: "Ann"}, Items: []item{{Price: 1.5}}},
+		})
+		ctx := expressions.NewContext(resolver, &expressions.DefaultFunctions{})
+
+		g.It("should render canonical source", func() {
+			for s, expected := range map[string]string{
+				"a+b*c":                   "a + b * c",
+				"((a + b)) * c":           "(a + b) * c",
+				"(a - b) - c":             "a - b - c",
+				"a - (b - c)":             "a - (b - c)",
+				"a / (b * c)":             "a / (b * c)",
+				"(a ^ b) ^ c":             "a ^ b ^ c",
+				"a ^ (b ^ c)":             "a ^ (b ^ c)",
+				"-(a + b)":                "-(a + b)",
+				"(-a) ^ 2":                "-a ^ 2",
+				"-(a ^ 2)":                "-(a ^ 2)",
+				"- -a":                    "--a",
+				"+a":                      "+a",
+				"!(a && b)":               "!(a && b)",
+				"(flag || a) && b":        "(flag || a) && b",
+				"flag || (a && b)":        "flag || a && b",
+				"(a > b) == (b > a)":      "a > b == b > a",
+				"a == (b == c)":           "a == (b == c)",
+				"(a xor b) || c":          "a xor b || c",
+				"order.items[(1 + a)].price": "order.items[1 + a].price",
+				"(a + 1).name":            "(a + 1).name",
+				"(-1)[0]":                 "(-1)[0]",
+				"if(a>1,\"yes\",null)":    "if(a > 1, \"yes\", null)",
+				"atan2( a , -b )":         "atan2(a, -b)",
+				"1.50 + 2e3 + 18446744073709551615": "1.5 + 2000.0 + 18446744073709551615",
+				"true != false":           "true != false",
+				"(a ? b : c)":             "a ? b : c",
+				"a || b ? (c) : (a + 1)":  "a || b ? c : a + 1",
+				"(a ? b : c) ? 1 : 2":     "(a ? b : c) ? 1 : 2",
+				"a ? (b ? 1 : 2) : (c ? 3 : 4)": "a ? b ? 1 : 2 : c ? 3 : 4",
+				"(a ? b : c) + 1":         "(a ? b : c) + 1",
+				"f(a ? b : c)[a ? 0 : 1]": "f(a ? b : c)[a ? 0 : 1]",
+				"'it\\'s' + \"\\\"q\\\"\"":  "\"it's\" + \"\\\"q\\\"\"",
+				"'a\\\\b\\n\\u0009\\u0001é'":    "\"a\\\\b\\n\\t\\u0001é\"",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				Expect(expr.(fmt.Stringer).String()).To(Equal(expected), s)
+			}
+		})
+
+		g.It("should compile back to an expression that solves the same way", func() {
+			for _, s := range []string{
+				"a + b * c - a / 2 % 3", "(a + b) * (c - a) ^ 2", "-a ^ -c", "!flag || !(a > b)",
+				"a >= b && b <= c xor name != \"john\"", "order.customer.Name", "list[a - 3] + list[2]",
+				"sqrt(a * 12) + cos(pi * 2)", "if(flag, a, b) * -(1 - c)", "1e-7 * 3.25e10", "0 - -c",
+				"7 / 2", "7.0 / 2", "2 ^ 0.5", "(1 + (2 + (3 + a)))", "a * (b * (c * 2))",
+				"flag ? a : b", "(a > b ? a : b) * 2", "(flag ? a : b) ? c : a", "a < 0 ? -1 : a > 0 ? 1 : 0",
+				"name + ' \\\\ ' + \"\\\"x\\\"\\r\\n\"", "name < 'k'",
+				"2 * i + a", "(1 + i) ^ 2 * b", "i * i == -1",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				expected, err := expr.Solve(ctx)
+				Expect(err).To(BeNil(), s)
+
+				source := expr.(fmt.Stringer).String()
+				printed, err := expressions.Compile(source)
+				Expect(err).To(BeNil(), source)
+				Expect(printed.(fmt.Stringer).String()).To(Equal(source))
+				v, err := printed.Solve(ctx)
+				Expect(err).To(BeNil(), source)
+				Expect(v).To(Equal(expected), source)
+			}
+		})
+
+		g.It("should render constants that have no literal by name", func() {
+			compiler := expressions.NewCompiler().SetConstant("origin", []interface{}{0.0, 0.0})
+			for s, expected := range map[string]string{
+				"origin[0]*2":   "origin[0] * 2",
+				"x + origin[1]": "x + origin[1]",
+				"f((origin))":   "f(origin)",
+			} {
+				expr, err := compiler.Compile(s)
+				Expect(err).To(BeNil(), s)
+				source := expr.(fmt.Stringer).String()
+				Expect(source).To(Equal(expected), s)
+				printed, err := compiler.Compile(source)
+				Expect(err).To(BeNil(), source)
+				Expect(printed.(fmt.Stringer).String()).To(Equal(source))
+			}
+		})
+
+		g.It("should render complex numbers with i", func() {
+			for s, expected := range map[string]string{
+				"2*i":       "2 * i",
+				"x + i":     "x + i",
+				"(1 - i)^2": "(1 - i) ^ 2",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				Expect(expr.(fmt.Stringer).String()).To(Equal(expected), s)
+			}
+
+			for value, expected := range map[complex128]string{
+				complex(0, 1):    "i",
+				complex(0, -1):   "-i",
+				complex(0, 2.5):  "2.5 * i",
+				complex(0, -2):   "-2.0 * i",
+				complex(1, -2):   "1.0 - 2.0 * i",
+				complex(-1.5, 1): "-1.5 + i",
+				complex(3, 0):    "3.0 + 0.0 * i",
+			} {
+				source := expressions.NewExpressionValue(value).String()
+				Expect(source).To(Equal(expected))
+				expr, err := expressions.Compile(source)
+				Expect(err).To(BeNil(), source)
+				v, err := expr.Solve(expressions.NewContext(nil, nil))
+				Expect(err).To(BeNil(), source)
+				Expect(v).To(Equal(value), source)
+			}
+			Expect(expressions.NewExpressionMultiple()).NotTo(BeNil())
+		})
+
+		g.It("should render expressions built by hand", func() {
+			sum := expressions.NewExpressionMultiple()
+			sum.Add("", expressions.NewExpressionField("a"))
+			sum.Add("+", expressions.NewExpressionBrackets(expressions.NewExpressionField("b")))
+			sum.Add("*", expressions.NewExpressionValue(2.0))
+			Expect(sum.String()).To(Equal("(a + b) * 2.0"))
+
+			Expect(expressions.NewExpressionValue(int64(-3)).String()).To(Equal("-3"))
+			Expect(expressions.NewExpressionIndex(expressions.NewExpressionValue(int64(-3)), expressions.NewExpressionValue(0)).String()).To(Equal("(-3)[0]"))
+			Expect(expressions.NewExpressionValue(math.Inf(1)).String()).To(Equal("1.0 / 0"))
+			Expect(expressions.NewExpressionMultiple().String()).To(Equal("0"))
+
+			// A single term must be a number, which the text has to keep.
+			single := expressions.NewExpressionMultiple()
+			single.Add("", expressions.NewExpressionField("name"))
+			Expect(single.String()).To(Equal("name * 1"))
+			printed, err := expressions.Compile(single.String())
+			Expect(err).To(BeNil())
+			_, expected := single.Solve(ctx)
+			Expect(expected).NotTo(BeNil())
+			_, err = printed.Solve(ctx)
+			Expect(err).To(MatchError(ContainSubstring(expected.Error())))
+		})
+	})
+
+	g.Describe("Printer", func() {
+		g.It("should keep short expressions in a line", func() {
+			expr, err := expressions.Compile("a + b * c")
+			Expect(err).To(BeNil())
+			Expect(expressions.Pretty(expr)).To(Equal("a + b * c"))
+		})
+
+		g.It("should break long expressions", func() {
+			expr, err := expressions.Compile("price * quantity + if(discount > 0, price * discount, 0) - (shipping + handling) * tax")
+			Expect(err).To(BeNil())
+			Expect(expressions.NewPrinter().SetWidth(30).Print(expr)).To(Equal(`price * quantity
++ if(
+    discount > 0,
+    price * discount,
+    0
+  )
+- (shipping + handling) * tax`))
+			Expect(expressions.NewPrinter().SetWidth(20).SetIndent("\t").Print(expr)).To(Equal(`price * quantity
++ if(
+		discount > 0,
+		price * discount,
+		0
+	)
+- (
+		shipping
+		+ handling
+	)
+	* tax`))
+		})
+
+		g.It("should break long conditionals", func() {
+			expr, err := expressions.Compile("total > 100 ? total * (1 - discount) : total + shipping")
+			Expect(err).To(BeNil())
+			Expect(expressions.NewPrinter().SetWidth(30).Print(expr)).To(Equal(`total > 100
+  ? total * (1 - discount)
+  : total + shipping`))
+		})
+
+		g.It("should print deeply nested expressions in linear time", func() {
+			s := strings.Repeat("(", 200) + "a" + strings.Repeat(" + b) * c", 200)
+			expr, err := expressions.Compile(s)
+			Expect(err).To(BeNil())
+			printed, err := expressions.Compile(expressions.NewPrinter().SetWidth(20).Print(expr))
+			Expect(err).To(BeNil())
+			Expect(printed.(fmt.Stringer).String()).To(Equal(expr.(fmt.Stringer).String()))
+		})
+
+				g.It("should compile back what it breaks", func() {
+			expr, err := expressions.Compile(pricingFormula)
+			Expect(err).To(BeNil())
+			printed, err := expressions.Compile(expressions.NewPrinter().SetWidth(10).Print(expr))
+			Expect(err).To(BeNil())
+			Expect(printed.(fmt.Stringer).String()).To(Equal(expr.(fmt.Stringer).String()))
+		})
+	})
+}
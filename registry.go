@@ -0,0 +1,221 @@
+package expressions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// LazyFunction receives the parameters unsolved, so it can decide which of them
+// are evaluated (e.g. the `if` function).
+type LazyFunction func(ctx Context, params ...Expression) (interface{}, error)
+
+type FunctionRegistry struct {
+	mutex     sync.RWMutex
+	functions map[string]LazyFunction
+}
+
+var (
+	contextType   = reflect.TypeOf((*Context)(nil)).Elem()
+	goContextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+func NewFunctionRegistry() *FunctionRegistry {
+	return &FunctionRegistry{
+		functions: make(map[string]LazyFunction),
+	}
+}
+
+// Register adds a plain Go function to the registry. Its parameters are solved
+// and converted to the types the function expects before it is called: any Go
+// numeric kind is accepted for numeric parameters, as long as the value fits.
+// Variadic functions are supported and, if the first parameter is a Context,
+// the context of the evaluation is passed to it. A context.Context first
+// parameter receives the one given to SolveContext. The function must return a
+// single value or a value and an error.
+func (registry *FunctionRegistry) Register(name string, fn interface{}) error {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func {
+		return errors.New(fmt.Sprintf("'%s' is not a function.", name))
+	}
+	ft := fv.Type()
+	if ft.NumOut() != 1 && !(ft.NumOut() == 2 && ft.Out(1) == errorType) {
+		return errors.New(fmt.Sprintf("'%s' must return a value or a value and an error.", name))
+	}
+	in := make([]reflect.Type, ft.NumIn())
+	for i := range in {
+		in[i] = ft.In(i)
+	}
+	withContext := len(in) > 0 && (in[0] == contextType || in[0] == goContextType)
+	if withContext {
+		in = in[1:]
+	}
+	variadic := ft.IsVariadic()
+	required := len(in)
+	if variadic {
+		required--
+	}
+
+	registry.RegisterLazy(name, func(ctx Context, params ...Expression) (interface{}, error) {
+		if len(params) < required || (!variadic && len(params) > required) {
+			return nil, &ParameterError{
+				name:       name,
+				paramCount: uint(required),
+				variadic:   variadic,
+			}
+		}
+		args := make([]reflect.Value, 0, len(params)+1)
+		switch {
+		case !withContext:
+		case ft.In(0) == goContextType:
+			goCtx := ContextOf(ctx)
+			args = append(args, reflect.ValueOf(&goCtx).Elem())
+		default:
+			args = append(args, reflect.ValueOf(&ctx).Elem())
+		}
+		for i, p := range params {
+			t := in[len(in)-1]
+			if i < required {
+				t = in[i]
+			} else {
+				t = t.Elem()
+			}
+			v, err := p.Solve(ctx)
+			if err != nil {
+				return nil, err
+			}
+			arg, ok := convertArgument(v, t)
+			if !ok {
+				return nil, NewWrongTypeError(v)
+			}
+			args = append(args, arg)
+		}
+		return callFunction(name, fv, args)
+	})
+	return nil
+}
+
+// callFunction calls fv, turning a panic inside it into an error that names the
+// function instead of bringing the whole evaluation down.
+func callFunction(name string, fv reflect.Value, args []reflect.Value) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, errors.New(fmt.Sprintf("The function '%s' panicked: %v", name, r))
+		}
+	}()
+	out := fv.Call(args)
+	if len(out) == 2 && !out[1].IsNil() {
+		return nil, out[1].Interface().(error)
+	}
+	return out[0].Interface(), nil
+}
+
+// MustRegister is like Register but panics when fn cannot be registered.
+func (registry *FunctionRegistry) MustRegister(name string, fn interface{}) *FunctionRegistry {
+	if err := registry.Register(name, fn); err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+func (registry *FunctionRegistry) RegisterLazy(name string, fn LazyFunction) *FunctionRegistry {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.functions[name] = fn
+	return registry
+}
+
+func (registry *FunctionRegistry) Unregister(name string) *FunctionRegistry {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	delete(registry.functions, name)
+	return registry
+}
+
+func (registry *FunctionRegistry) Has(name string) bool {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	_, ok := registry.functions[name]
+	return ok
+}
+
+func (registry *FunctionRegistry) Call(ctx Context, name string, params ... Expression) (interface{}, error) {
+	registry.mutex.RLock()
+	fn, ok := registry.functions[name]
+	registry.mutex.RUnlock()
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("The function '%s' is not defined.", name))
+	}
+	return fn(ctx, params...)
+}
+
+// convertArgument converts a solved value to the type of a function parameter.
+func convertArgument(v interface{}, t reflect.Type) (reflect.Value, bool) {
+	if v == nil {
+		switch t.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return reflect.Zero(t), true
+		}
+		return reflect.Value{}, false
+	}
+	rv := reflect.ValueOf(v)
+	if n, ok := normalizeNumber(v); ok && t.Kind() != reflect.Interface {
+		switch t.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f, _ := toFloat64(n)
+			r := reflect.New(t).Elem()
+			if r.OverflowFloat(f) {
+				return reflect.Value{}, false
+			}
+			r.SetFloat(f)
+			return r, true
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			r := reflect.New(t).Elem()
+			switch nn := n.(type) {
+			case int64:
+				if r.OverflowInt(nn) {
+					return reflect.Value{}, false
+				}
+				r.SetInt(nn)
+			case float64:
+				if nn != math.Trunc(nn) || nn < math.MinInt64 || nn >= math.MaxInt64 || r.OverflowInt(int64(nn)) {
+					return reflect.Value{}, false
+				}
+				r.SetInt(int64(nn))
+			default:
+				return reflect.Value{}, false
+			}
+			return r, true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			r := reflect.New(t).Elem()
+			switch nn := n.(type) {
+			case int64:
+				if nn < 0 || r.OverflowUint(uint64(nn)) {
+					return reflect.Value{}, false
+				}
+				r.SetUint(uint64(nn))
+			case uint64:
+				if r.OverflowUint(nn) {
+					return reflect.Value{}, false
+				}
+				r.SetUint(nn)
+			case float64:
+				if nn != math.Trunc(nn) || nn < 0 || nn >= math.MaxUint64 || r.OverflowUint(uint64(nn)) {
+					return reflect.Value{}, false
+				}
+				r.SetUint(uint64(nn))
+			}
+			return r, true
+		}
+	}
+	if rv.Type().AssignableTo(t) {
+		return rv, true
+	}
+	if rv.Kind() == t.Kind() && rv.Type().ConvertibleTo(t) {
+		return rv.Convert(t), true
+	}
+	return reflect.Value{}, false
+}
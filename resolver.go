@@ -3,6 +3,7 @@ package expressions
 import (
 	"errors"
 	"fmt"
+	"reflect"
 )
 
 type Resolver interface {
@@ -25,3 +26,35 @@ func (resolver *MapResolver) Resolve(name string) (interface{}, error) {
 	}
 	return nil, errors.New(fmt.Sprintf("Value of %s was not found.", name))
 }
+
+type StructResolver struct {
+	v reflect.Value
+}
+
+// NewStructResolver creates a resolver for the fields and zero-argument methods
+// of a struct or pointer to a struct. Fields are matched by their `expr` tag,
+// their `json` tag or their name, in that order. Only the methods of v can be
+// called: the members of the values it resolves are limited to their fields.
+func NewStructResolver(v interface{}) *StructResolver {
+	return &StructResolver{
+		v: reflect.ValueOf(v),
+	}
+}
+
+func (resolver *StructResolver) Resolve(name string) (interface{}, error) {
+	rv := resolver.v
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, errors.New(fmt.Sprintf("Value of %s was not found.", name))
+	}
+	if rv.Kind() != reflect.Struct && (rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct) {
+		return nil, errors.New(fmt.Sprintf("Value of %s was not found.", name))
+	}
+	v, ok, err := lookupStructMember(rv, name, true)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Value of %s was not found.", name))
+	}
+	return v, nil
+}
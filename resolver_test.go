@@ -1,12 +1,46 @@
 package expressions_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"github.com/jamillosantos/go-expressions"
 	. "github.com/franela/goblin"
 	. "github.com/onsi/gomega"
 )
 
+type base struct {
+	ID int64 `json:"id"`
+}
+
+type product struct {
+	base
+	Name     string  `json:"name"`
+	Price    float64 `expr:"value" json:"price"`
+	Internal string  `json:"-"`
+	stock    int
+}
+
+func (p product) Label() string {
+	return "#" + p.Name
+}
+
+func (p *product) InStock() (bool, error) {
+	if p.stock < 0 {
+		return false, errors.New("invalid stock")
+	}
+	return p.stock > 0, nil
+}
+
+type node struct {
+	*node
+	Value int64
+}
+
+func (p product) Discount(percent float64) float64 {
+	return p.Price * percent
+}
+
 func TestMapResolver(t *testing.T) {
 	g := Goblin(t)
 
@@ -27,5 +61,129 @@ func TestMapResolver(t *testing.T) {
 				Expect(v).To(Equal(float64(2.34)))
 			})
 		})
+
+		g.Describe("StructResolver", func() {
+			p := &product{
+				base: base{
+					ID: 42,
+				},
+				Name:     "Pencil",
+				Price:    1.5,
+				Internal: "internal",
+				stock:    3,
+			}
+
+			g.It("should resolve fields by name and json tag", func() {
+				resolver := expressions.NewStructResolver(p)
+				v, err := resolver.Resolve("name")
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal("Pencil"))
+				v, err = resolver.Resolve("Name")
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal("Pencil"))
+			})
+
+			g.It("should give the expr tag precedence", func() {
+				resolver := expressions.NewStructResolver(p)
+				v, err := resolver.Resolve("value")
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(1.5))
+			})
+
+			g.It("should resolve promoted fields of embedded structs", func() {
+				resolver := expressions.NewStructResolver(*p)
+				v, err := resolver.Resolve("id")
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(int64(42)))
+			})
+
+			g.It("should not resolve ignored or unexported fields", func() {
+				resolver := expressions.NewStructResolver(p)
+				_, err := resolver.Resolve("Internal")
+				Expect(err).NotTo(BeNil())
+				_, err = resolver.Resolve("stock")
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(ContainSubstring("was not found"))
+			})
+
+			g.It("should resolve zero-argument methods", func() {
+				resolver := expressions.NewStructResolver(p)
+				v, err := resolver.Resolve("Label")
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal("#Pencil"))
+				v, err = resolver.Resolve("InStock")
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+				_, err = resolver.Resolve("Discount")
+				Expect(err).NotTo(BeNil())
+			})
+
+			g.It("should return the error of methods", func() {
+				resolver := expressions.NewStructResolver(&product{stock: -1})
+				_, err := resolver.Resolve("InStock")
+				Expect(err).NotTo(BeNil())
+				Expect(fmt.Sprint(err)).To(Equal("invalid stock"))
+			})
+
+			g.It("should not call methods of the values it resolves", func() {
+				type order struct {
+					Product *product
+				}
+				resolver := expressions.NewStructResolver(&order{Product: p})
+				expr, err := expressions.Compile("Product.name")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(resolver, nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal("Pencil"))
+				for _, formula := range []string{"Product.Label", "Product[\"InStock\"]"} {
+					expr, err = expressions.Compile(formula)
+					Expect(err).To(BeNil())
+					_, err = expr.Solve(expressions.NewContext(resolver, nil))
+					Expect(err).NotTo(BeNil())
+					Expect(fmt.Sprint(err)).To(ContainSubstring("has no field"))
+				}
+			})
+
+			g.It("should only resolve pointer methods from pointers", func() {
+				resolver := expressions.NewStructResolver(*p)
+				_, err := resolver.Resolve("InStock")
+				Expect(err).NotTo(BeNil())
+			})
+
+			g.It("should resolve types that embed themselves", func() {
+				resolver := expressions.NewStructResolver(&node{Value: 1, node: &node{Value: 2}})
+				v, err := resolver.Resolve("Value")
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(int64(1)))
+				_, err = resolver.Resolve("node")
+				Expect(err).NotTo(BeNil())
+			})
+
+			g.It("should fail with values that are not structs", func() {
+				var nilProduct *product
+				_, err := expressions.NewStructResolver(nilProduct).Resolve("name")
+				Expect(err).NotTo(BeNil())
+				_, err = expressions.NewStructResolver(42).Resolve("name")
+				Expect(err).NotTo(BeNil())
+			})
+
+			g.It("should be used to solve expressions", func() {
+				expr, err := expressions.Compile("value * 2 > 2 && InStock && Label == \"#Pencil\"")
+				Expect(err).To(BeNil())
+				v, err := expr.Solve(expressions.NewContext(expressions.NewStructResolver(p), nil))
+				Expect(err).To(BeNil())
+				Expect(v).To(Equal(true))
+			})
+		})
+	})
+}
+
+func BenchmarkStructResolver(b *testing.B) {
+	resolver := expressions.NewStructResolver(&product{
+		Name:  "Pencil",
+		Price: 1.5,
 	})
+	for i := 0; i < b.N; i++ {
+		resolver.Resolve("value")
+	}
 }
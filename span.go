@@ -0,0 +1,86 @@
+package expressions
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Span locates a node in the source it was compiled from. Start and End are
+// character offsets (End is exclusive) and Line and Column follow the same
+// convention of SyntaxError.
+type Span struct {
+	Start  int
+	End    int
+	Line   int
+	Column int
+	// source is shared by all the spans of a compilation, which only copy
+	// their text out of it when asked.
+	source []rune
+}
+
+// Text returns the source of the node.
+func (span *Span) Text() string {
+	if span.End > len(span.source) || span.Start > span.End {
+		return ""
+	}
+	return string(span.source[span.Start:span.End])
+}
+
+func (span *Span) String() string {
+	return fmt.Sprintf("`%s` at %d:%d", span.Text(), span.Line, span.Column)
+}
+
+// Spanned is implemented by the nodes that can keep their source span. Nodes
+// built by hand have no span.
+type Spanned interface {
+	Span() *Span
+	SetSpan(span *Span)
+}
+
+type spanned struct {
+	span *Span
+}
+
+func (s *spanned) Span() *Span {
+	return s.span
+}
+
+func (s *spanned) SetSpan(span *Span) {
+	s.span = span
+}
+
+// wrap locates an error raised by the node itself. Errors already located by
+// a node deeper in the tree are returned as they are.
+func (s *spanned) wrap(kind string, err error, operands ...interface{}) error {
+	if err == nil || s.span == nil {
+		return err
+	}
+	var located *RuntimeError
+	if errors.As(err, &located) {
+		return err
+	}
+	return &RuntimeError{
+		Span:     *s.span,
+		Kind:     kind,
+		Operands: operands,
+		Err:      err,
+	}
+}
+
+// RuntimeError is returned when solving a compiled expression fails. It points
+// to the sub-expression that failed, and keeps the values it was working on,
+// while the original error can be reached with errors.Is and errors.As.
+type RuntimeError struct {
+	Span     Span
+	Kind     string
+	Operands []interface{}
+	Err      error
+}
+
+func (err *RuntimeError) Error() string {
+	return fmt.Sprintf("%s (%s %s)", err.Err.Error(), err.Kind, err.Span.String())
+}
+
+func (err *RuntimeError) Unwrap() error {
+	return err.Err
+}
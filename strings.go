@@ -0,0 +1,185 @@
+package expressions
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The string functions of DefaultFunctions. Lengths, positions and widths are
+// counted in characters (Unicode code points), not in bytes.
+
+// stringLength is the `len` function.
+func stringLength(s string) int64 {
+	return int64(utf8.RuneCountInString(s))
+}
+
+// substr returns the characters of s from start, counted from the end when
+// negative, up to the given length or the end of s.
+func substr(s string, start int, length ...int) (string, error) {
+	if len(length) > 1 {
+		return "", &ParameterError{
+			name:       "substr",
+			paramCount: 2,
+			optional:   1,
+		}
+	}
+	r := []rune(s)
+	if start < 0 {
+		start += len(r)
+	}
+	start = clamp(start, 0, len(r))
+	end := len(r)
+	if len(length) == 1 {
+		end = clamp(length[0], 0, end-start) + start
+	}
+	return string(r[start:end]), nil
+}
+
+func clamp(n int, min int, max int) int {
+	switch {
+	case n < min:
+		return min
+	case n > max:
+		return max
+	}
+	return n
+}
+
+// replace replaces all the occurrences of old in s with new. An empty old
+// matches before every character and at the end, as in strings.ReplaceAll.
+func replace(ctx Context, s string, old string, new string) (string, error) {
+	n := strings.Count(s, old)
+	// The copies of new are checked first, so computing the size of the
+	// result cannot overflow.
+	if err := checkSize(ctx, "replace", n, len(new)); err != nil {
+		return "", err
+	}
+	if err := checkSize(ctx, "replace", 1, len(s)+n*(len(new)-len(old))); err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(s, old, new), nil
+}
+
+func split(s string, separator string) []interface{} {
+	parts := strings.Split(s, separator)
+	r := make([]interface{}, len(parts))
+	for i, part := range parts {
+		r[i] = part
+	}
+	return r
+}
+
+// join renders the items of a list, which can be a slice or an array of any
+// type, separated by separator.
+func join(list interface{}, separator string) (string, error) {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", NewWrongTypeError(list)
+	}
+	items := make([]string, v.Len())
+	for i := range items {
+		items[i] = text(v.Index(i).Interface())
+	}
+	return strings.Join(items, separator), nil
+}
+
+// padLeft pads s with spaces, or the characters of pad, until it is width
+// characters long.
+func padLeft(ctx Context, s string, width int, pad ...string) (string, error) {
+	fill := " "
+	switch len(pad) {
+	case 0:
+	case 1:
+		fill = pad[0]
+	default:
+		return "", &ParameterError{
+			name:       "padLeft",
+			paramCount: 2,
+			optional:   1,
+		}
+	}
+	if fill == "" {
+		return "", errors.New("The padding of 'padLeft' cannot be empty.")
+	}
+	n := width - utf8.RuneCountInString(s)
+	if n <= 0 {
+		return s, nil
+	}
+	count := n/utf8.RuneCountInString(fill) + 1
+	if err := checkSize(ctx, "padLeft", count, len(fill)); err != nil {
+		return "", err
+	}
+	padding := []rune(strings.Repeat(fill, count))
+	return string(padding[:n]) + s, nil
+}
+
+func repeat(ctx Context, s string, count int) (string, error) {
+	if count < 0 {
+		return "", errors.New(fmt.Sprintf("'repeat' cannot repeat %d times.", count))
+	}
+	if err := checkSize(ctx, "repeat", count, len(s)); err != nil {
+		return "", err
+	}
+	return strings.Repeat(s, count), nil
+}
+
+// formatText is the `format` function. It replaces the placeholders {0},
+// {1}, ... of pattern by the arguments at those positions; `{{` and `}}` are
+// written as single braces.
+func formatText(pattern string, args ...interface{}) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if (c == '{' || c == '}') && i+1 < len(pattern) && pattern[i+1] == c {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		if c == '{' {
+			if end := strings.IndexByte(pattern[i:], '}'); end > 1 {
+				if n, ok := placeholder(pattern[i+1 : i+end]); ok {
+					if n >= len(args) {
+						return "", errors.New(fmt.Sprintf("The placeholder {%d} of 'format' has no value.", n))
+					}
+					b.WriteString(text(args[n]))
+					i += end
+					continue
+				}
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), nil
+}
+
+func placeholder(s string) (int, bool) {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+// text renders a value in the strings built by functions: strings as they
+// are, null as nothing and numbers without exponents.
+func text(v interface{}) string {
+	switch vv := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return vv
+	}
+	if n, ok := normalizeNumber(v); ok {
+		if f, ok := n.(float64); ok {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		return fmt.Sprint(n)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,140 @@
+package expressions
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type structMember struct {
+	index    []int
+	method   int
+	priority int
+}
+
+const (
+	memberPriorityMethod = iota
+	memberPriorityEmbedded
+	memberPriorityName
+	memberPriorityJSON
+	memberPriorityExpr
+)
+
+var (
+	errorType          = reflect.TypeOf((*error)(nil)).Elem()
+	structMembersCache sync.Map
+)
+
+// structMembers returns, for a struct or pointer to struct type, the members
+// that can be accessed by name. The `expr` tag has precedence over the `json`
+// tag, which has precedence over the field name and then over zero-argument
+// methods. The result is cached per type.
+func structMembers(t reflect.Type) map[string]structMember {
+	if m, ok := structMembersCache.Load(t); ok {
+		return m.(map[string]structMember)
+	}
+	members := make(map[string]structMember)
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		if m.PkgPath != "" || m.Type.NumIn() != 1 {
+			continue
+		}
+		if m.Type.NumOut() == 1 || (m.Type.NumOut() == 2 && m.Type.Out(1) == errorType) {
+			members[m.Name] = structMember{
+				method:   i,
+				priority: memberPriorityMethod,
+			}
+		}
+	}
+	st := t
+	if st.Kind() == reflect.Ptr {
+		st = st.Elem()
+	}
+	if st.Kind() == reflect.Struct {
+		addStructFields(members, st, nil, false, map[reflect.Type]bool{})
+	}
+	structMembersCache.Store(t, members)
+	return members
+}
+
+// addStructFields adds the fields of t and, with a lower priority, the ones
+// promoted from its embedded structs. Structs already visited are skipped, so
+// types that embed themselves do not recurse forever.
+func addStructFields(members map[string]structMember, t reflect.Type, index []int, embedded bool, visited map[reflect.Type]bool) {
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+	var anonymous []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			anonymous = append(anonymous, f)
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		fIndex := append(append([]int{}, index...), i)
+		add := func(name string, priority int) {
+			if embedded {
+				priority = memberPriorityEmbedded
+			}
+			if name == "" || name == "-" {
+				return
+			}
+			if current, ok := members[name]; ok && current.priority >= priority {
+				return
+			}
+			members[name] = structMember{
+				index:    fIndex,
+				method:   -1,
+				priority: priority,
+			}
+		}
+		if f.Tag.Get("json") != "-" {
+			add(f.Name, memberPriorityName)
+			add(strings.Split(f.Tag.Get("json"), ",")[0], memberPriorityJSON)
+		}
+		add(strings.Split(f.Tag.Get("expr"), ",")[0], memberPriorityExpr)
+	}
+	for _, f := range anonymous {
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			addStructFields(members, ft, append(append([]int{}, index...), f.Index...), true, visited)
+		}
+	}
+}
+
+// lookupStructMember resolves name on rv, a struct or a pointer to a struct.
+// Methods are only called when methods is set. The bool result is false when
+// the type has no such member.
+func lookupStructMember(rv reflect.Value, name string, methods bool) (interface{}, bool, error) {
+	member, ok := structMembers(rv.Type())[name]
+	if !ok || (member.method >= 0 && !methods) {
+		return nil, false, nil
+	}
+	if member.method >= 0 {
+		out := rv.Method(member.method).Call(nil)
+		if len(out) == 2 && !out[1].IsNil() {
+			return nil, true, out[1].Interface().(error)
+		}
+		return out[0].Interface(), true, nil
+	}
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	for i, fi := range member.index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return nil, true, errors.New("the embedded struct is nil")
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(fi)
+	}
+	return rv.Interface(), true, nil
+}
@@ -0,0 +1,427 @@
+package expressions
+
+import (
+	"math"
+)
+
+type opcode byte
+
+const (
+	// opConst pushes constants[arg].
+	opConst opcode = iota
+	// opSolve pushes the result of solving node. It is used for fields and
+	// for the expressions the program compiler does not know.
+	opSolve
+	// opMember replaces the top of the stack with its member text.
+	opMember
+	// opIndex pops the index and replaces the top with the indexed value.
+	opIndex
+	// opNumber normalizes the number at the top of the stack.
+	opNumber
+	// opArithmetic pops the right operand and combines it with the top of
+	// the stack using the operator text. arg holds the operator decoded, so
+	// floats can be combined without going through arithmetic.
+	opArithmetic
+	// opCompare pops the right operand and compares it with the top of the
+	// stack using the operator text. arg holds relational operators decoded.
+	opCompare
+	// opTruthy replaces the top of the stack with its truthiness.
+	opTruthy
+	opNot
+	// opXor pops a boolean and replaces the top with the xor of both.
+	opXor
+	// opJumpIfTrue and opJumpIfFalse jump to arg when the boolean at the top
+	// matches. Otherwise they pop it.
+	opJumpIfTrue
+	opJumpIfFalse
+	// opBranch pops a value and jumps to arg when it is not truthy. opJump
+	// always jumps to arg.
+	opBranch
+	opJump
+	// opCall pushes the result of calling the function text with the
+	// parameters params[arg].
+	opCall
+)
+
+// Decoded operators of opArithmetic and opCompare. Zero means the operator is
+// only handled by the slow path.
+const (
+	operatorAdd = iota + 1
+	operatorSub
+	operatorMul
+	operatorDiv
+	operatorMod
+	operatorPow
+	operatorGT
+	operatorLT
+	operatorGTE
+	operatorLTE
+)
+
+var decodedOperators = map[string]int{
+	"+":  operatorAdd,
+	"-":  operatorSub,
+	"*":  operatorMul,
+	"/":  operatorDiv,
+	"%":  operatorMod,
+	"^":  operatorPow,
+	">":  operatorGT,
+	"<":  operatorLT,
+	">=": operatorGTE,
+	"<=": operatorLTE,
+}
+
+// stackBuffer is the size of the stack Solve allocates in its frame. Programs
+// that need more allocate it.
+const stackBuffer = 16
+
+// instruction is an operation of a Program. steps is how many nodes start
+// being evaluated at the instruction, which count for Limits.MaxSteps before it
+// runs, in the same order the nodes of the tree count them.
+type instruction struct {
+	op    opcode
+	arg   int
+	text  string
+	node  Expression
+	steps int
+}
+
+// Program is an expression compiled to a flat list of instructions executed by
+// a stack machine, which avoids walking the tree on every Solve. Solving a
+// program gives the same results and errors as solving the expression it was
+// built from.
+type Program struct {
+	code      []instruction
+	constants []interface{}
+	params    [][]Expression
+	stackSize int
+	depth     int
+}
+
+// NewProgram compiles an expression into a Program. Parameters of functions
+// are compiled into their own programs, since functions receive them unsolved.
+func NewProgram(expression Expression) *Program {
+	p := &Program{}
+	p.compile(expression)
+	return p
+}
+
+func (p *Program) emit(op opcode, arg int, text string, node Expression) int {
+	p.code = append(p.code, instruction{
+		op:   op,
+		arg:  arg,
+		text: text,
+		node: node,
+	})
+	switch op {
+	case opConst, opSolve, opCall:
+		p.depth++
+	case opIndex, opArithmetic, opCompare, opXor, opJumpIfTrue, opJumpIfFalse, opBranch:
+		p.depth--
+	}
+	if p.depth > p.stackSize {
+		p.stackSize = p.depth
+	}
+	return len(p.code) - 1
+}
+
+// step counts the evaluation of a node at the first instruction of its code,
+// which starts at i, before its operands are solved.
+func (p *Program) step(i int) {
+	p.code[i].steps++
+}
+
+func (p *Program) compile(expression Expression) {
+	start := len(p.code)
+	switch e := expression.(type) {
+	case *ExpressionValue:
+		p.constants = append(p.constants, e.value)
+		p.emit(opConst, len(p.constants)-1, "", e)
+	case *ExpressionMember:
+		p.compile(e.expression)
+		p.emit(opMember, 0, e.name, e)
+		p.step(start)
+	case *ExpressionIndex:
+		p.compile(e.expression)
+		p.compile(e.index)
+		p.emit(opIndex, 0, "", e)
+		p.step(start)
+	case *ExpressionMultiple:
+		for i, term := range e.terms {
+			p.compile(term.expression)
+			if i == 0 {
+				p.emit(opNumber, 0, "", e)
+			} else {
+				p.emit(opArithmetic, decodedOperators[term.operator], term.operator, e)
+			}
+		}
+		if len(e.terms) == 0 {
+			p.compile(NewExpressionValue(int64(0)))
+		}
+		p.step(start)
+	case *ExpressionBinary:
+		p.compile(e.left)
+		p.compile(e.right)
+		p.emit(opCompare, decodedOperators[e.operator], e.operator, e)
+		p.step(start)
+	case *ExpressionLogical:
+		var jump opcode
+		switch e.operator {
+		case "||":
+			jump = opJumpIfTrue
+		case "&&":
+			jump = opJumpIfFalse
+		case "xor":
+			p.compile(e.left)
+			p.emit(opTruthy, 0, "", e)
+			p.compile(e.right)
+			p.emit(opTruthy, 0, "", e)
+			p.emit(opXor, 0, "", e)
+			p.step(start)
+			return
+		default:
+			p.emit(opSolve, 0, "", e)
+			return
+		}
+		p.compile(e.left)
+		p.emit(opTruthy, 0, "", e)
+		j := p.emit(jump, 0, "", e)
+		p.compile(e.right)
+		p.emit(opTruthy, 0, "", e)
+		p.code[j].arg = len(p.code)
+		p.step(start)
+	case *ExpressionNot:
+		p.compile(e.expression)
+		p.emit(opNot, 0, "", e)
+		p.step(start)
+	case *ExpressionBrackets:
+		p.compile(e.inner)
+	case *ExpressionConditional:
+		p.compile(e.condition)
+		branch := p.emit(opBranch, 0, "", e)
+		p.compile(e.then)
+		jump := p.emit(opJump, 0, "", e)
+		// Only one of the branches leaves its value on the stack.
+		p.depth--
+		p.code[branch].arg = len(p.code)
+		p.compile(e.otherwise)
+		p.code[jump].arg = len(p.code)
+		p.step(start)
+	case *ExpressionFunction:
+		params := make([]Expression, len(e.params))
+		for i, param := range e.params {
+			params[i] = NewProgram(param)
+		}
+		p.params = append(p.params, params)
+		p.emit(opCall, len(p.params)-1, e.name, e)
+		p.step(start)
+	default:
+		p.emit(opSolve, 0, "", e)
+	}
+}
+
+func (p *Program) Solve(ctx Context) (interface{}, error) {
+	var buffer [stackBuffer]interface{}
+	stack := buffer[:0]
+	if p.stackSize > stackBuffer {
+		stack = make([]interface{}, 0, p.stackSize)
+	}
+	ctx = limited(ctx)
+	state, _ := ctx.(*solveContext)
+	for pc := 0; pc < len(p.code); pc++ {
+		in := &p.code[pc]
+		if state != nil {
+			if err := state.check(in.steps); err != nil {
+				return nil, err
+			}
+		}
+		top := len(stack) - 1
+		switch in.op {
+		case opConst:
+			stack = append(stack, p.constants[in.arg])
+		case opSolve:
+			v, err := in.node.Solve(ctx)
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, v)
+		case opMember:
+			v, err := in.node.(*ExpressionMember).lookup(stack[top])
+			if err != nil {
+				return nil, err
+			}
+			if state != nil {
+				if err := state.checkLength(v); err != nil {
+					return nil, err
+				}
+			}
+			stack[top] = v
+		case opIndex:
+			v, err := in.node.(*ExpressionIndex).lookup(stack[top-1], stack[top])
+			if err != nil {
+				return nil, err
+			}
+			if state != nil {
+				if err := state.checkLength(v); err != nil {
+					return nil, err
+				}
+			}
+			stack = stack[:top]
+			stack[top-1] = v
+		case opNumber:
+			v, err := in.node.(*ExpressionMultiple).first(stack[top])
+			if err != nil {
+				return nil, err
+			}
+			stack[top] = v
+		case opArithmetic:
+			if l, r, ok := floatOperands(stack[top-1], stack[top]); ok && in.arg != 0 {
+				stack[top-1] = floatOperation(in.arg, l, r)
+			} else if l, r, ok := intOperands(stack[top-1], stack[top]); ok && in.arg != 0 {
+				v, err := intArithmetic(l, in.text, r)
+				if err != nil {
+					return nil, in.node.(*ExpressionMultiple).wrap("arithmetic", err, stack[top-1], stack[top])
+				}
+				stack[top-1] = v
+			} else {
+				v, err := in.node.(*ExpressionMultiple).combine(stack[top-1], in.text, stack[top])
+				if err != nil {
+					return nil, err
+				}
+				if state != nil {
+					if err := state.checkLength(v); err != nil {
+						return nil, err
+					}
+				}
+				stack[top-1] = v
+			}
+			stack = stack[:top]
+		case opCompare:
+			if c, ok := fastCompare(in.arg, stack[top-1], stack[top]); ok {
+				stack[top-1] = c
+			} else {
+				v, err := in.node.(*ExpressionBinary).compare(ctx, stack[top-1], stack[top])
+				if err != nil {
+					return nil, err
+				}
+				stack[top-1] = v
+			}
+			stack = stack[:top]
+		case opTruthy:
+			stack[top] = IsTruthy(stack[top])
+		case opNot:
+			stack[top] = !IsTruthy(stack[top])
+		case opXor:
+			stack[top-1] = stack[top-1].(bool) != stack[top].(bool)
+			stack = stack[:top]
+		case opJumpIfTrue, opJumpIfFalse:
+			if stack[top].(bool) == (in.op == opJumpIfTrue) {
+				pc = in.arg - 1
+			} else {
+				stack = stack[:top]
+			}
+		case opBranch:
+			v := stack[top]
+			stack = stack[:top]
+			if !IsTruthy(v) {
+				pc = in.arg - 1
+			}
+		case opJump:
+			pc = in.arg - 1
+		case opCall:
+			v, err := in.node.(*ExpressionFunction).call(ctx, p.params[in.arg])
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, v)
+		}
+	}
+	return stack[0], nil
+}
+
+// floatOperands returns both operands as float64 when they are float64 or
+// int64 and at least one is a float64, the case in which arithmetic uses
+// floatArithmetic.
+func floatOperands(left interface{}, right interface{}) (float64, float64, bool) {
+	var l, r float64
+	lFloat, rFloat := false, false
+	switch n := left.(type) {
+	case float64:
+		l, lFloat = n, true
+	case int64:
+		l = float64(n)
+	default:
+		return 0, 0, false
+	}
+	switch n := right.(type) {
+	case float64:
+		r, rFloat = n, true
+	case int64:
+		r = float64(n)
+	default:
+		return 0, 0, false
+	}
+	return l, r, lFloat || rFloat
+}
+
+func intOperands(left interface{}, right interface{}) (int64, int64, bool) {
+	l, lok := left.(int64)
+	r, rok := right.(int64)
+	return l, r, lok && rok
+}
+
+// floatOperation does what floatArithmetic does for decoded operators.
+func floatOperation(operator int, l float64, r float64) float64 {
+	switch operator {
+	case operatorAdd:
+		return l + r
+	case operatorSub:
+		return l - r
+	case operatorMul:
+		return l * r
+	case operatorDiv:
+		return l / r
+	case operatorMod:
+		return math.Mod(l, r)
+	default:
+		return math.Pow(l, r)
+	}
+}
+
+// fastCompare compares two numbers that are float64 or int64 with a decoded
+// relational operator. The bool result is false when it cannot handle them.
+func fastCompare(operator int, left interface{}, right interface{}) (bool, bool) {
+	var c int
+	if l, r, ok := floatOperands(left, right); ok {
+		if math.IsNaN(l) || math.IsNaN(r) {
+			c = unordered
+		} else {
+			c = compareOrdered(l < r, l > r)
+		}
+	} else if l, r, ok := intOperands(left, right); ok {
+		c = compareOrdered(l < r, l > r)
+	} else {
+		return false, false
+	}
+	switch operator {
+	case operatorGT:
+		return c == 1, true
+	case operatorLT:
+		return c == -1, true
+	case operatorGTE:
+		return c == 0 || c == 1, true
+	case operatorLTE:
+		return c == 0 || c == -1, true
+	}
+	return false, false
+}
+
+func compareOrdered(less bool, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,157 @@
+package expressions_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"github.com/jamillosantos/go-expressions"
+	. "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+const pricingFormula = "(price * quantity * (1 - discount) + shipping) * (1 + tax) - rebate * (quantity - 10) / quantity"
+
+func pricingResolver() expressions.Resolver {
+	return expressions.NewMapResolver(map[string]interface{}{
+		"price":    49.9,
+		"quantity": int64(12),
+		"discount": 0.15,
+		"shipping": int64(20),
+		"tax":      0.08,
+		"rebate":   1.25,
+	})
+}
+
+func TestProgram(t *testing.T) {
+	g := Goblin(t)
+
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Program", func() {
+		resolver := expressions.NewMapResolver(map[string]interface{}{
+			"a":     int64(3),
+			"b":     2.5,
+			"name":  "john",
+			"flag":  true,
+			"list":  []interface{}{int64(1), "two", 3.0},
+			"order": &order{Customer: customer{Name: "Ann"}, Items: []item{{Price: 1.5}}},
+			"nan":   math.NaN(),
+		})
+		ctx := expressions.NewContext(resolver, &expressions.DefaultFunctions{})
+
+		g.It("should solve like the expression it was built from", func() {
+			for _, s := range []string{
+				"1", "a", "-a", "a + b * 2 - 1", "7 / 2", "2 ^ 10 % 7", "18446744073709551615 - 1",
+				"a > b", "a == 3.0", "name != \"john\"", "1 < 2 == true",
+				"flag && a > 1", "!flag || b", "a xor 0", "flag && name && list",
+				"order.customer.Name", "order.items[0].price * a", "list[1]", "list[a - 1] + 1", "name[0]",
+				"if(flag, a, missing)", "sqrt(16) + cos(0)", "if(a > 1, if(b > 2, \"x\", \"y\"), \"z\")",
+				"b * 2.0 > 4.5", "a >= 3", "b / 0", "0.0 / 0 > 1",
+				"flag ? a : missing", "a > 5 ? missing : b", "a ? name : list", "(flag ? a : b) * 2 + (0 ? 1 : 2)",
+				"a > 5 ? 1 : a > 2 ? 2 : 3", "flag ? !flag ? 1 : 2 : 3",
+				"name + \" \" + 'doe'", "name < \"k\"", "name >= name + \"\"",
+				"nan > 1", "nan < b", "nan >= 0", "a <= nan", "nan >= nan", "nan == nan", "nan != a",
+				"sqrt(-1) >= 0", "sqrt(-1) <= 0", "!(0.0 / 0 < 1)",
+				"i * a + 1", "(1 - i) ^ 2 / b", "i ^ 2 == -1", "i != i * 1.0",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				expected, err := expr.Solve(ctx)
+				Expect(err).To(BeNil(), s)
+				v, err := expressions.NewProgram(expr).Solve(ctx)
+				Expect(err).To(BeNil(), s)
+				Expect(v).To(Equal(expected), s)
+			}
+		})
+
+		g.It("should fail like the expression it was built from", func() {
+			for _, s := range []string{
+				"missing", "a + name", "name * 2", "a / (a - 3)", "a > name", "order.customer.phone",
+				"list[5]", "sqrt(1, 2, 3)", "unknown(1)", "flag && missing", "!missing", "a + (b > 1)",
+				"missing ? 1 : 2", "flag ? missing : 1", "!flag ? 1 : name * 2",
+				"name + a", "a + name", "name + name - name", "name > a",
+			} {
+				expr, err := expressions.Compile(s)
+				Expect(err).To(BeNil(), s)
+				_, expected := expr.Solve(ctx)
+				Expect(expected).NotTo(BeNil(), s)
+				_, err = expressions.NewProgram(expr).Solve(ctx)
+				Expect(err).To(Equal(expected), s)
+			}
+		})
+
+		g.It("should short-circuit logical operators", func() {
+			expr, err := expressions.Compile("flag || missing")
+			Expect(err).To(BeNil())
+			v, err := expressions.NewProgram(expr).Solve(ctx)
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(true))
+
+			expr, err = expressions.Compile("!flag && missing")
+			Expect(err).To(BeNil())
+			v, err = expressions.NewProgram(expr).Solve(ctx)
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(false))
+		})
+
+		g.It("should solve expressions built by hand", func() {
+			expr := expressions.NewExpressionMultiple()
+			expr.Add("", expressions.NewExpressionBrackets(expressions.NewExpressionField("a")))
+			expr.Add("*", expressions.NewExpressionValue(2))
+			v, err := expressions.NewProgram(expr).Solve(ctx)
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(int64(6)))
+
+			program := expressions.NewProgram(expressions.NewExpressionLogical(expressions.NewExpressionValue(true), "nand", expressions.NewExpressionValue(true)))
+			_, err = program.Solve(ctx)
+			Expect(err).NotTo(BeNil())
+		})
+
+		g.It("should keep the location of runtime errors", func() {
+			expr, err := expressions.Compile("a * 2 + name")
+			Expect(err).To(BeNil())
+			_, err = expressions.NewProgram(expr).Solve(ctx)
+			var runtimeErr *expressions.RuntimeError
+			Expect(errors.As(err, &runtimeErr)).To(BeTrue())
+			Expect(runtimeErr.Span.Text()).To(Equal("a * 2 + name"))
+			Expect(runtimeErr.Operands).To(Equal([]interface{}{int64(6), "john"}))
+		})
+
+		g.It("should be safe for concurrent use", func() {
+			expr, err := expressions.Compile(pricingFormula)
+			Expect(err).To(BeNil())
+			expected, err := expr.Solve(expressions.NewContext(pricingResolver(), &expressions.DefaultFunctions{}))
+			Expect(err).To(BeNil())
+			program := expressions.NewProgram(expr)
+			results := make(chan interface{}, 50)
+			for i := 0; i < cap(results); i++ {
+				go func() {
+					v, _ := program.Solve(expressions.NewContext(pricingResolver(), &expressions.DefaultFunctions{}))
+					results <- v
+				}()
+			}
+			for i := 0; i < cap(results); i++ {
+				Expect(<-results).To(Equal(expected))
+			}
+		})
+	})
+}
+
+func BenchmarkSolveTree(b *testing.B) {
+	expr, _ := expressions.Compile(pricingFormula)
+	ctx := expressions.NewContext(pricingResolver(), &expressions.DefaultFunctions{})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		expr.Solve(ctx)
+	}
+}
+
+func BenchmarkSolveProgram(b *testing.B) {
+	expr, _ := expressions.Compile(pricingFormula)
+	program := expressions.NewProgram(expr)
+	ctx := expressions.NewContext(pricingResolver(), &expressions.DefaultFunctions{})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		program.Solve(ctx)
+	}
+}
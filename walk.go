@@ -0,0 +1,126 @@
+package expressions
+
+// Visitor is called by Walk for every expression of a tree. When Visit returns
+// a visitor, Walk visits the sub-expressions with it and then calls its Visit
+// with nil.
+type Visitor interface {
+	Visit(e Expression) Visitor
+}
+
+// Walk visits an expression and its sub-expressions, depth first and in the
+// order they are solved. Expressions of types unknown to the package are
+// visited as they have no sub-expressions.
+func Walk(v Visitor, e Expression) {
+	if e == nil {
+		return
+	}
+	if v = v.Visit(e); v == nil {
+		return
+	}
+	for _, c := range children(e) {
+		Walk(v, c)
+	}
+	v.Visit(nil)
+}
+
+type inspector func(Expression) bool
+
+func (f inspector) Visit(e Expression) Visitor {
+	if f(e) {
+		return f
+	}
+	return nil
+}
+
+// Inspect walks an expression calling f for it and, while f returns true, for
+// its sub-expressions. After the sub-expressions, f is called with nil.
+func Inspect(e Expression, f func(Expression) bool) {
+	Walk(inspector(f), e)
+}
+
+// Rewrite rebuilds an expression bottom-up, replacing every expression with
+// what f returns for it. f receives the expressions with their sub-expressions
+// already rewritten and may return them as they are. Expressions are only
+// copied when their sub-expressions change, and the copies keep the span of
+// the expressions they replace. The expression given is not changed.
+func Rewrite(e Expression, f func(Expression) Expression) Expression {
+	if e == nil {
+		return nil
+	}
+	cs := children(e)
+	rewritten := make([]Expression, len(cs))
+	changed := false
+	for i, c := range cs {
+		rewritten[i] = Rewrite(c, f)
+		if rewritten[i] != c {
+			changed = true
+		}
+	}
+	if changed {
+		e = withChildren(e, rewritten)
+	}
+	return f(e)
+}
+
+// children returns the sub-expressions of e in the order they are solved.
+func children(e Expression) []Expression {
+	switch ee := e.(type) {
+	case *ExpressionMember:
+		return []Expression{ee.expression}
+	case *ExpressionIndex:
+		return []Expression{ee.expression, ee.index}
+	case *ExpressionMultiple:
+		r := make([]Expression, len(ee.terms))
+		for i, term := range ee.terms {
+			r[i] = term.expression
+		}
+		return r
+	case *ExpressionBinary:
+		return []Expression{ee.left, ee.right}
+	case *ExpressionLogical:
+		return []Expression{ee.left, ee.right}
+	case *ExpressionNot:
+		return []Expression{ee.expression}
+	case *ExpressionBrackets:
+		return []Expression{ee.inner}
+	case *ExpressionFunction:
+		return ee.params
+	case *ExpressionConditional:
+		return []Expression{ee.condition, ee.then, ee.otherwise}
+	}
+	return nil
+}
+
+// withChildren copies e replacing its sub-expressions, given in the order
+// children returns them.
+func withChildren(e Expression, cs []Expression) Expression {
+	var r Expression
+	switch ee := e.(type) {
+	case *ExpressionMember:
+		r = NewExpressionMember(cs[0], ee.name)
+	case *ExpressionIndex:
+		r = NewExpressionIndex(cs[0], cs[1])
+	case *ExpressionMultiple:
+		m := NewExpressionMultiple()
+		for i, term := range ee.terms {
+			m.Add(term.operator, cs[i])
+		}
+		r = m
+	case *ExpressionBinary:
+		r = NewExpressionBinary(cs[0], ee.operator, cs[1])
+	case *ExpressionLogical:
+		r = NewExpressionLogical(cs[0], ee.operator, cs[1])
+	case *ExpressionNot:
+		r = NewExpressionNot(cs[0])
+	case *ExpressionBrackets:
+		r = NewExpressionBrackets(cs[0])
+	case *ExpressionFunction:
+		r = NewExpressionFunction(ee.name, cs...)
+	case *ExpressionConditional:
+		r = NewExpressionConditional(cs[0], cs[1], cs[2])
+	default:
+		return e
+	}
+	copySpan(r, e)
+	return r
+}
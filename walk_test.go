@@ -0,0 +1,137 @@
+package expressions_test
+
+import (
+	"fmt"
+	"testing"
+	"github.com/jamillosantos/go-expressions"
+	. "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+type recorder struct {
+	visited []string
+	depth   int
+}
+
+func (r *recorder) Visit(e expressions.Expression) expressions.Visitor {
+	if e == nil {
+		r.depth--
+		return nil
+	}
+	r.visited = append(r.visited, fmt.Sprintf("%d %s", r.depth, e))
+	r.depth++
+	return r
+}
+
+func TestWalk(t *testing.T) {
+	g := Goblin(t)
+
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Accessors", func() {
+		g.It("should expose the nodes of a compiled expression", func() {
+			expr, err := expressions.Compile("order.items[1] + f(x, 2) > !flag")
+			Expect(err).To(BeNil())
+
+			binary := expr.(*expressions.ExpressionBinary)
+			Expect(binary.Operator()).To(Equal(">"))
+			Expect(binary.Right().(*expressions.ExpressionNot).Expression().(*expressions.ExpressionField).Field()).To(Equal("flag"))
+
+			terms := binary.Left().(*expressions.ExpressionMultiple).Terms()
+			Expect(terms).To(HaveLen(2))
+			Expect(terms[0].Operator()).To(Equal(""))
+			Expect(terms[1].Operator()).To(Equal("+"))
+
+			index := terms[0].Expression().(*expressions.ExpressionIndex)
+			Expect(index.Index().(*expressions.ExpressionValue).Value()).To(Equal(int64(1)))
+			member := index.Expression().(*expressions.ExpressionMember)
+			Expect(member.Name()).To(Equal("items"))
+			Expect(member.Expression().(*expressions.ExpressionField).Field()).To(Equal("order"))
+
+			function := terms[1].Expression().(*expressions.ExpressionFunction)
+			Expect(function.Name()).To(Equal("f"))
+			Expect(function.Params()).To(HaveLen(2))
+		})
+	})
+
+	g.Describe("Walk", func() {
+		g.It("should visit expressions depth first in the order they are solved", func() {
+			expr, err := expressions.Compile("a && f(b, c.d)")
+			Expect(err).To(BeNil())
+			r := &recorder{}
+			expressions.Walk(r, expr)
+			Expect(r.visited).To(Equal([]string{"0 a && f(b, c.d)", "1 a", "1 f(b, c.d)", "2 b", "2 c.d", "3 c"}))
+			Expect(r.depth).To(Equal(0))
+		})
+
+		g.It("should skip the sub-expressions Inspect rejects", func() {
+			expr, err := expressions.Compile("a + f(b) + (c + d)")
+			Expect(err).To(BeNil())
+			var fields []string
+			expressions.Inspect(expr, func(e expressions.Expression) bool {
+				switch ee := e.(type) {
+				case *expressions.ExpressionField:
+					fields = append(fields, ee.Field())
+				case *expressions.ExpressionFunction:
+					return false
+				}
+				return true
+			})
+			Expect(fields).To(Equal([]string{"a", "c", "d"}))
+		})
+	})
+
+	g.Describe("Rewrite", func() {
+		g.It("should rename variables", func() {
+			expr, err := expressions.Compile("price * (qty + 1) - price")
+			Expect(err).To(BeNil())
+			r := expressions.Rewrite(expr, func(e expressions.Expression) expressions.Expression {
+				if f, ok := e.(*expressions.ExpressionField); ok && f.Field() == "price" {
+					return expressions.NewExpressionField("unit_price")
+				}
+				return e
+			})
+			Expect(r.(fmt.Stringer).String()).To(Equal("unit_price * (qty + 1) - unit_price"))
+			Expect(expr.(fmt.Stringer).String()).To(Equal("price * (qty + 1) - price"))
+		})
+
+		g.It("should expand macros", func() {
+			expr, err := expressions.Compile("double(a + 1) > 2")
+			Expect(err).To(BeNil())
+			r := expressions.Rewrite(expr, func(e expressions.Expression) expressions.Expression {
+				if f, ok := e.(*expressions.ExpressionFunction); ok && f.Name() == "double" {
+					m := expressions.NewExpressionMultiple()
+					m.Add("", f.Params()[0])
+					m.Add("*", expressions.NewExpressionValue(int64(2)))
+					return m
+				}
+				return e
+			})
+			Expect(r.(fmt.Stringer).String()).To(Equal("(a + 1) * 2 > 2"))
+			v, err := r.Solve(expressions.NewContext(expressions.NewMapResolver(map[string]interface{}{"a": int64(1)}), nil))
+			Expect(err).To(BeNil())
+			Expect(v).To(Equal(true))
+		})
+
+		g.It("should keep what it does not change", func() {
+			expr, err := expressions.Compile("a + b * c")
+			Expect(err).To(BeNil())
+			Expect(expressions.Rewrite(expr, func(e expressions.Expression) expressions.Expression {
+				return e
+			})).To(BeIdenticalTo(expr))
+		})
+
+		g.It("should keep the spans of the expressions it copies", func() {
+			expr, err := expressions.Compile("a + missing")
+			Expect(err).To(BeNil())
+			r := expressions.Rewrite(expr, func(e expressions.Expression) expressions.Expression {
+				if f, ok := e.(*expressions.ExpressionField); ok && f.Field() == "a" {
+					return expressions.NewExpressionValue(int64(1))
+				}
+				return e
+			})
+			Expect(r).NotTo(BeIdenticalTo(expr))
+			Expect(r.(expressions.Spanned).Span().Text()).To(Equal("a + missing"))
+		})
+	})
+}